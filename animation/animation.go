@@ -0,0 +1,165 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package animation provides a utility to play frame animations from a sprite sheet.
+package animation
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Mode represents how an animation proceeds after its last frame.
+type Mode int
+
+const (
+	// ModeLoop restarts the animation from the first frame after the last frame.
+	ModeLoop Mode = iota
+
+	// ModeOnce stops the animation at the last frame.
+	ModeOnce
+
+	// ModePingPong plays the animation backward after the last frame, and forward again after the first frame.
+	// The first and the last frames are not repeated at the turns.
+	ModePingPong
+)
+
+// Animation represents a frame animation in a sprite sheet.
+//
+// An Animation doesn't have its own clock. Pass the number of ticks elapsed since the animation started,
+// which is typically counted up in Update.
+type Animation struct {
+	sheet       *ebiten.Image
+	frameWidth  int
+	frameHeight int
+	frameCount  int
+	fps         float64
+	mode        Mode
+
+	frames []*ebiten.Image
+}
+
+// NewAnimation creates a new Animation.
+//
+// The frames are taken from sheet from left to right, and then top to bottom, in the size of frameWidth x frameHeight.
+// sheet can be a sub-image of a bigger atlas.
+// fps is the number of frames played per second.
+//
+// NewAnimation panics if the frames don't fit in sheet, or fps is not positive.
+func NewAnimation(sheet *ebiten.Image, frameWidth, frameHeight, frameCount int, fps float64, mode Mode) *Animation {
+	if frameWidth <= 0 || frameHeight <= 0 {
+		panic(fmt.Sprintf("animation: frame size must be positive but (%d, %d)", frameWidth, frameHeight))
+	}
+	if fps <= 0 {
+		panic(fmt.Sprintf("animation: fps must be positive but %f", fps))
+	}
+	columns := sheet.Bounds().Dx() / frameWidth
+	rows := sheet.Bounds().Dy() / frameHeight
+	if frameCount <= 0 || frameCount > columns*rows {
+		panic(fmt.Sprintf("animation: frameCount must be in between 1 and %d but %d", columns*rows, frameCount))
+	}
+
+	return &Animation{
+		sheet:       sheet,
+		frameWidth:  frameWidth,
+		frameHeight: frameHeight,
+		frameCount:  frameCount,
+		fps:         fps,
+		mode:        mode,
+		frames:      make([]*ebiten.Image, frameCount),
+	}
+}
+
+// FrameCount returns the number of the frames.
+func (a *Animation) FrameCount() int {
+	return a.frameCount
+}
+
+// FrameIndex returns the index of the frame at the given tick.
+//
+// tick is the number of ticks elapsed since the animation started.
+// The time is calculated with the current TPS. If the TPS is SyncWithFPS, DefaultTPS is used instead.
+func (a *Animation) FrameIndex(tick int64) int {
+	tps := ebiten.TPS()
+	if tps <= 0 {
+		tps = ebiten.DefaultTPS
+	}
+	return a.frameIndex(tick, tps)
+}
+
+func (a *Animation) frameIndex(tick int64, tps int) int {
+	if tick < 0 {
+		return 0
+	}
+
+	// Add a small value to avoid a rounding error at a frame boundary.
+	n := int64(float64(tick)*a.fps/float64(tps) + 1e-9)
+
+	count := int64(a.frameCount)
+	switch a.mode {
+	case ModeLoop:
+		return int(n % count)
+	case ModeOnce:
+		if n >= count {
+			return int(count - 1)
+		}
+		return int(n)
+	case ModePingPong:
+		if count == 1 {
+			return 0
+		}
+		period := 2*count - 2
+		i := n % period
+		if i >= count {
+			i = period - i
+		}
+		return int(i)
+	default:
+		panic(fmt.Sprintf("animation: invalid mode: %d", a.mode))
+	}
+}
+
+// IsFinished reports whether the animation reaches its last frame at the given tick in ModeOnce.
+// IsFinished always returns false in the other modes.
+func (a *Animation) IsFinished(tick int64) bool {
+	if a.mode != ModeOnce {
+		return false
+	}
+	return a.FrameIndex(tick) == a.frameCount-1
+}
+
+// FrameRect returns the rectangle of the frame with the given index in the sheet.
+func (a *Animation) FrameRect(index int) image.Rectangle {
+	if index < 0 || index >= a.frameCount {
+		panic(fmt.Sprintf("animation: index must be in between 0 and %d but %d", a.frameCount-1, index))
+	}
+	columns := a.sheet.Bounds().Dx() / a.frameWidth
+	x := a.sheet.Bounds().Min.X + (index%columns)*a.frameWidth
+	y := a.sheet.Bounds().Min.Y + (index/columns)*a.frameHeight
+	return image.Rect(x, y, x+a.frameWidth, y+a.frameHeight)
+}
+
+// Frame returns the frame image and its rectangle in the sheet at the given tick.
+//
+// The returned image is a sub-image of the sheet, and is reused for the same frame.
+func (a *Animation) Frame(tick int64) (*ebiten.Image, image.Rectangle) {
+	index := a.FrameIndex(tick)
+	r := a.FrameRect(index)
+	if a.frames[index] == nil {
+		a.frames[index] = a.sheet.SubImage(r).(*ebiten.Image)
+	}
+	return a.frames[index], r
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/animation"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+func TestFrameIndex(t *testing.T) {
+	// 6 frames in a 4x2 grid. At 10 FPS and 60 TPS, one frame lasts 6 ticks.
+	sheet := ebiten.NewImage(40, 20)
+	ticksPerFrame := int64(ebiten.TPS() / 10)
+
+	cases := []struct {
+		Mode  animation.Mode
+		Frame int64
+		Want  int
+	}{
+		{animation.ModeLoop, 0, 0},
+		{animation.ModeLoop, 5, 5},
+		{animation.ModeLoop, 6, 0},
+		{animation.ModeLoop, 13, 1},
+		{animation.ModeOnce, 5, 5},
+		{animation.ModeOnce, 6, 5},
+		{animation.ModeOnce, 100, 5},
+		{animation.ModePingPong, 5, 5},
+		{animation.ModePingPong, 6, 4},
+		{animation.ModePingPong, 9, 1},
+		{animation.ModePingPong, 10, 0},
+		{animation.ModePingPong, 11, 1},
+	}
+	for _, c := range cases {
+		a := animation.NewAnimation(sheet, 10, 10, 6, 10, c.Mode)
+		tick := c.Frame * ticksPerFrame
+		// The frame is kept until the last tick of the frame.
+		if got := a.FrameIndex(tick); got != c.Want {
+			t.Errorf("mode: %d, FrameIndex(%d): got: %d, want: %d", c.Mode, tick, got, c.Want)
+		}
+		if c.Frame > 0 {
+			prev := animation.NewAnimation(sheet, 10, 10, 6, 10, c.Mode).FrameIndex(tick - 1)
+			if prev == c.Want && c.Mode != animation.ModeOnce {
+				t.Errorf("mode: %d, FrameIndex(%d): the frame must change at the tick %d", c.Mode, tick-1, tick)
+			}
+		}
+	}
+}
+
+func TestFrameRect(t *testing.T) {
+	atlas := ebiten.NewImage(64, 64)
+	sheet := atlas.SubImage(image.Rect(8, 16, 48, 36)).(*ebiten.Image)
+	a := animation.NewAnimation(sheet, 10, 10, 6, 10, animation.ModeLoop)
+
+	if got, want := a.FrameRect(0), image.Rect(8, 16, 18, 26); got != want {
+		t.Errorf("FrameRect(0): got: %v, want: %v", got, want)
+	}
+	if got, want := a.FrameRect(5), image.Rect(18, 26, 28, 36); got != want {
+		t.Errorf("FrameRect(5): got: %v, want: %v", got, want)
+	}
+
+	img, r := a.Frame(0)
+	if got, want := img.Bounds(), r; got != want {
+		t.Errorf("Frame(0): got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Analyser keeps a rolling window of the recent samples of a stream and analyses them.
+// Analyser is useful for e.g. music visualizers.
+//
+// An Analyser is attached to a player by (*Player).SetAnalyser.
+// The samples are mixed down to mono for analysis.
+type Analyser struct {
+	// window is a ring buffer of the recent samples in [-1, 1].
+	window []float64
+	head   int
+
+	// rest is the bytes of an incomplete sample from the last Write.
+	rest  [bytesPerSampleInt16]byte
+	restN int
+
+	// hann, cos, sin and reversed are precomputed tables for the FFT.
+	hann     []float64
+	cos      []float64
+	sin      []float64
+	reversed []int
+
+	// re, im and magnitudes are buffers reused across Magnitudes calls.
+	re         []float64
+	im         []float64
+	magnitudes []float64
+
+	m sync.Mutex
+}
+
+// NewAnalyser creates a new Analyser with the given window size in samples.
+//
+// windowSize must be a power of 2 and at least 2. NewAnalyser panics otherwise.
+// A bigger window gives a finer frequency resolution, but reacts more slowly to changes.
+// For example, 2048 samples are about 46ms at 44100 Hz.
+func NewAnalyser(windowSize int) *Analyser {
+	if windowSize < 2 || windowSize&(windowSize-1) != 0 {
+		panic(fmt.Sprintf("audio: windowSize must be a power of 2 and at least 2 but %d", windowSize))
+	}
+
+	a := &Analyser{
+		window:   make([]float64, windowSize),
+		hann:     make([]float64, windowSize),
+		cos:      make([]float64, windowSize/2),
+		sin:      make([]float64, windowSize/2),
+		reversed: make([]int, windowSize),
+		re:       make([]float64, windowSize),
+		im:       make([]float64, windowSize),
+	}
+	for i := range a.hann {
+		a.hann[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(windowSize))
+	}
+	for i := range a.cos {
+		a.cos[i] = math.Cos(2 * math.Pi * float64(i) / float64(windowSize))
+		a.sin[i] = -math.Sin(2 * math.Pi * float64(i) / float64(windowSize))
+	}
+	var bits int
+	for 1<<bits < windowSize {
+		bits++
+	}
+	for i := range a.reversed {
+		var r int
+		for j := 0; j < bits; j++ {
+			if i&(1<<j) != 0 {
+				r |= 1 << (bits - 1 - j)
+			}
+		}
+		a.reversed[i] = r
+	}
+	return a
+}
+
+// WindowSize returns the window size in samples.
+func (a *Analyser) WindowSize() int {
+	return len(a.window)
+}
+
+// Write adds the given stream bytes to the window.
+//
+// The stream format must be 16-bit little endian and 2 channels.
+// Write is called automatically for an Analyser attached to a player.
+// Write never returns an error.
+func (a *Analyser) Write(buf []byte) (int, error) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	n := len(buf)
+
+	// Complete the incomplete sample from the last Write first.
+	if a.restN > 0 {
+		c := copy(a.rest[a.restN:], buf)
+		a.restN += c
+		buf = buf[c:]
+		if a.restN < bytesPerSampleInt16 {
+			return n, nil
+		}
+		a.push(a.rest[:])
+		a.restN = 0
+	}
+
+	alignedN := len(buf) / bytesPerSampleInt16 * bytesPerSampleInt16
+	for i := 0; i < alignedN; i += bytesPerSampleInt16 {
+		a.push(buf[i : i+bytesPerSampleInt16])
+	}
+	a.restN = copy(a.rest[:], buf[alignedN:])
+	return n, nil
+}
+
+func (a *Analyser) push(sample []byte) {
+	l := float64(int16(sample[0]) | int16(sample[1])<<8)
+	r := float64(int16(sample[2]) | int16(sample[3])<<8)
+	a.window[a.head] = (l + r) / 2 / (1 << 15)
+	a.head = (a.head + 1) % len(a.window)
+}
+
+// RMS returns the root mean square of the samples in the window, in [0, 1].
+func (a *Analyser) RMS() float64 {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	var sum float64
+	for _, v := range a.window {
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(len(a.window)))
+}
+
+// Peak returns the maximum absolute value of the samples in the window, in [0, 1].
+func (a *Analyser) Peak() float64 {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	var peak float64
+	for _, v := range a.window {
+		peak = math.Max(peak, math.Abs(v))
+	}
+	return peak
+}
+
+// Magnitudes returns the magnitudes of the frequency spectrum of the window.
+//
+// The spectrum from 0 Hz to the half of the sample rate is divided evenly into the given number of bins,
+// and each value is the average magnitude in the bin.
+// When bins is the half of the window size, a full-scale sine wave whose frequency matches a bin has a magnitude of about 1 there.
+//
+// bins must be in between 1 and the half of the window size. Magnitudes panics otherwise.
+//
+// The returned slice is reused by the next call of Magnitudes, so Magnitudes doesn't allocate memory
+// as long as bins doesn't increase.
+// Copy the values if you want to keep them.
+func (a *Analyser) Magnitudes(bins int) []float64 {
+	if bins < 1 || bins > len(a.window)/2 {
+		panic(fmt.Sprintf("audio: bins must be in between 1 and %d but %d", len(a.window)/2, bins))
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	// Apply the window function from the oldest sample, and reorder the samples in the bit-reversed order.
+	size := len(a.window)
+	for i := 0; i < size; i++ {
+		a.re[a.reversed[i]] = a.window[(a.head+i)%size] * a.hann[i]
+		a.im[i] = 0
+	}
+
+	// Iterative radix-2 FFT.
+	for half := 1; half < size; half *= 2 {
+		step := size / (half * 2)
+		for start := 0; start < size; start += half * 2 {
+			for k := 0; k < half; k++ {
+				wr, wi := a.cos[k*step], a.sin[k*step]
+				i, j := start+k, start+k+half
+				tr := a.re[j]*wr - a.im[j]*wi
+				ti := a.re[j]*wi + a.im[j]*wr
+				a.re[j], a.im[j] = a.re[i]-tr, a.im[i]-ti
+				a.re[i], a.im[i] = a.re[i]+tr, a.im[i]+ti
+			}
+		}
+	}
+
+	if cap(a.magnitudes) < bins {
+		a.magnitudes = make([]float64, bins)
+	}
+	a.magnitudes = a.magnitudes[:bins]
+
+	// The sum of the Hann window is size/2. Normalize the magnitudes so that a full-scale sine wave has 1.
+	scale := 4 / float64(size)
+	freqs := size / 2
+	for b := 0; b < bins; b++ {
+		start := b * freqs / bins
+		end := (b + 1) * freqs / bins
+		var sum float64
+		for k := start; k < end; k++ {
+			sum += math.Hypot(a.re[k], a.im[k]) * scale
+		}
+		a.magnitudes[b] = sum / float64(end-start)
+	}
+	return a.magnitudes
+}
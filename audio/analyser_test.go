@@ -0,0 +1,75 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+func TestAnalyser(t *testing.T) {
+	const (
+		windowSize = 1024
+		bin        = 64
+	)
+
+	a := audio.NewAnalyser(windowSize)
+
+	// Write a full-scale sine wave whose frequency matches the bin, byte by byte for the first part.
+	b := make([]byte, 4*windowSize*2)
+	for i := 0; i < len(b)/4; i++ {
+		v := int16(math.Sin(2*math.Pi*bin*float64(i)/windowSize) * (1<<15 - 1))
+		b[4*i] = byte(v)
+		b[4*i+1] = byte(v >> 8)
+		b[4*i+2] = byte(v)
+		b[4*i+3] = byte(v >> 8)
+	}
+	for i := 0; i < 7; i++ {
+		if _, err := a.Write(b[i : i+1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := a.Write(b[7:]); err != nil {
+		t.Fatal(err)
+	}
+
+	ms := a.Magnitudes(windowSize / 2)
+	var maxIndex int
+	for i, m := range ms {
+		if m > ms[maxIndex] {
+			maxIndex = i
+		}
+	}
+	if got, want := maxIndex, bin; got != want {
+		t.Errorf("the index of the maximum magnitude: got: %d, want: %d", got, want)
+	}
+	if got, want := ms[bin], 1.0; math.Abs(got-want) > 0.01 {
+		t.Errorf("magnitude: got: %f, want: %f", got, want)
+	}
+	if got, want := a.RMS(), math.Sqrt2/2; math.Abs(got-want) > 0.01 {
+		t.Errorf("RMS(): got: %f, want: %f", got, want)
+	}
+	if got, want := a.Peak(), 1.0; math.Abs(got-want) > 0.01 {
+		t.Errorf("Peak(): got: %f, want: %f", got, want)
+	}
+
+	if n := testing.AllocsPerRun(10, func() {
+		a.Magnitudes(16)
+	}); n != 0 {
+		t.Errorf("allocations by Magnitudes: got: %f, want: 0", n)
+	}
+}
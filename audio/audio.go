@@ -37,8 +37,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"runtime"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/audio/internal/convert"
@@ -60,11 +63,13 @@ const (
 type Context struct {
 	playerFactory *playerFactory
 
-	sampleRate int
-	err        error
-	ready      bool
+	sampleRate   int
+	err          error
+	ready        bool
+	masterVolume float64
 
 	playingPlayers map[*playerImpl]struct{}
+	crossfaders    []*Crossfader
 
 	m         sync.Mutex
 	semaphore chan struct{}
@@ -75,6 +80,25 @@ var (
 	theContextLock sync.Mutex
 )
 
+const (
+	minBufferSize = 5 * time.Millisecond
+	maxBufferSize = time.Second
+)
+
+// NewContextOptions represents options for NewContextWithOptions.
+type NewContextOptions struct {
+	// BufferSize specifies the buffer size of the underlying audio device.
+	// The default (zero) value means that the driver's default buffer size is used.
+	//
+	// A smaller buffer reduces the latency between playing a sound and hearing it, which matters for e.g. rhythm games.
+	// On the other hand, a too small buffer can cause glitch noises due to buffer underruns, especially on low-power machines.
+	// A bigger buffer is more robust but increases the latency.
+	//
+	// The size in bytes is calculated from BufferSize, the sample rate and the number of channels by the driver.
+	// A non-zero BufferSize is clamped to [5ms, 1s].
+	BufferSize time.Duration
+}
+
 // NewContext creates a new audio context with the given sample rate.
 //
 // sampleRate specifies the number of samples that should be played during one second.
@@ -83,6 +107,15 @@ var (
 //
 // NewContext panics when an audio context is already created.
 func NewContext(sampleRate int) *Context {
+	return NewContextWithOptions(sampleRate, nil)
+}
+
+// NewContextWithOptions creates a new audio context with the given sample rate and the options.
+//
+// If options is nil, the default setting is used.
+//
+// NewContextWithOptions panics when an audio context is already created.
+func NewContextWithOptions(sampleRate int, options *NewContextOptions) *Context {
 	theContextLock.Lock()
 	defer theContextLock.Unlock()
 
@@ -90,11 +123,23 @@ func NewContext(sampleRate int) *Context {
 		panic("audio: context is already created")
 	}
 
+	var bufferSize time.Duration
+	if options != nil && options.BufferSize != 0 {
+		bufferSize = options.BufferSize
+		if bufferSize < minBufferSize {
+			bufferSize = minBufferSize
+		}
+		if bufferSize > maxBufferSize {
+			bufferSize = maxBufferSize
+		}
+	}
+
 	c := &Context{
 		sampleRate:     sampleRate,
-		playerFactory:  newPlayerFactory(sampleRate),
+		playerFactory:  newPlayerFactory(sampleRate, bufferSize),
 		playingPlayers: map[*playerImpl]struct{}{},
 		semaphore:      make(chan struct{}, 1),
+		masterVolume:   1,
 	}
 	theContext = c
 
@@ -147,6 +192,7 @@ func NewContext(sampleRate int) *Context {
 		if err := c.updatePlayers(); err != nil {
 			return err
 		}
+		c.updateCrossfaders()
 		return nil
 	})
 
@@ -205,6 +251,13 @@ func (c *Context) removePlayingPlayer(p *playerImpl) {
 	c.m.Unlock()
 }
 
+func (c *Context) isPlayingPlayer(p *playerImpl) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	_, ok := c.playingPlayers[p]
+	return ok
+}
+
 func (c *Context) onSuspend() error {
 	// A Context must not call playerImpl's functions with a lock, or this causes a deadlock (#2737).
 	// Copy the playerImpls and iterate them without a lock.
@@ -223,6 +276,10 @@ func (c *Context) onSuspend() error {
 		p.onContextSuspended()
 	}
 
+	for _, cf := range c.copyCrossfaders() {
+		cf.onContextSuspended()
+	}
+
 	return nil
 }
 
@@ -244,6 +301,10 @@ func (c *Context) onResume() error {
 		p.onContextResumed()
 	}
 
+	for _, cf := range c.copyCrossfaders() {
+		cf.onContextResumed()
+	}
+
 	return nil
 }
 
@@ -259,6 +320,7 @@ func (c *Context) updatePlayers() error {
 	c.m.Unlock()
 
 	var playersToRemove []*playerImpl
+	var callbacks []func()
 
 	// Now reader players cannot call removePlayers from themselves in the current implementation.
 	// Underlying playering can be the pause state after fishing its playing,
@@ -271,6 +333,11 @@ func (c *Context) updatePlayers() error {
 		p.updatePosition()
 		if !p.IsPlaying() {
 			playersToRemove = append(playersToRemove, p)
+			// The state might be changed after IsPlaying, e.g. by Pause on another goroutine.
+			// finishedCallbackIfEnded checks the state again with the player's lock.
+			if f := p.finishedCallbackIfEnded(); f != nil {
+				callbacks = append(callbacks, f)
+			}
 		}
 	}
 
@@ -280,9 +347,59 @@ func (c *Context) updatePlayers() error {
 	}
 	c.m.Unlock()
 
+	// Call the callbacks without a lock, as the callbacks might call the context's or the players' functions.
+	for _, f := range callbacks {
+		f()
+	}
+
 	return nil
 }
 
+func (c *Context) addCrossfader(cf *Crossfader) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.crossfaders = append(c.crossfaders, cf)
+}
+
+func (c *Context) copyCrossfaders() []*Crossfader {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return append([]*Crossfader(nil), c.crossfaders...)
+}
+
+func (c *Context) updateCrossfaders() {
+	// A crossfader calls the players' functions, so do not update crossfaders with a lock (#2737).
+	var finished []*Crossfader
+	for _, cf := range c.copyCrossfaders() {
+		if cf.update() {
+			finished = append(finished, cf)
+		}
+	}
+	if len(finished) == 0 {
+		return
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	cfs := c.crossfaders[:0]
+	for _, cf := range c.crossfaders {
+		var done bool
+		for _, f := range finished {
+			if cf == f {
+				done = true
+				break
+			}
+		}
+		if !done {
+			cfs = append(cfs, cf)
+		}
+	}
+	for i := len(cfs); i < len(c.crossfaders); i++ {
+		c.crossfaders[i] = nil
+	}
+	c.crossfaders = cfs
+}
+
 // IsReady returns a boolean value indicating whether the audio is ready or not.
 //
 // On some browsers, user interaction like click or pressing keys is required to start audio.
@@ -297,6 +414,43 @@ func (c *Context) SampleRate() int {
 	return c.sampleRate
 }
 
+// MasterVolume returns the master volume of the context [0-1].
+func (c *Context) MasterVolume() float64 {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.masterVolume
+}
+
+// SetMasterVolume sets the master volume of the context.
+// The master volume is multiplied by the volume of each player.
+// This is useful to lower all the sounds at once, e.g., when a pause menu is opened.
+//
+// volume must be in between 0 and 1. SetMasterVolume panics otherwise.
+//
+// The master volume is independent of suspending and resuming the context.
+// A zero master volume doesn't pause any players.
+func (c *Context) SetMasterVolume(volume float64) {
+	if volume < 0 || volume > 1 {
+		panic(fmt.Sprintf("audio: volume must be in between 0 and 1 but %f", volume))
+	}
+
+	// A Context must not call playerImpl's functions with a lock, or this causes a deadlock (#2737).
+	// Copy the playerImpls and iterate them without a lock.
+	var players []*playerImpl
+	c.m.Lock()
+	c.masterVolume = volume
+	players = make([]*playerImpl, 0, len(c.playingPlayers))
+	for p := range c.playingPlayers {
+		players = append(players, p)
+	}
+	c.m.Unlock()
+
+	// Players that are not playing are updated when they start playing.
+	for _, p := range players {
+		p.updateVolume()
+	}
+}
+
 // Player is an audio player which has one stream.
 //
 // Even when all references to a Player object is gone,
@@ -305,6 +459,36 @@ func (c *Context) SampleRate() int {
 // the object is never GCed unless Close is called.
 type Player struct {
 	p *playerImpl
+
+	// stack is the stack trace where the player is created.
+	// stack is recorded only when the leak check is enabled.
+	stack []byte
+}
+
+var (
+	leakCheck atomic.Bool
+
+	// leakCheckWriter is the destination of the leak reports.
+	// If leakCheckWriter is nil, os.Stderr is used.
+	// leakCheckWriter is atomic as the reports are written from the finalizer goroutine.
+	leakCheckWriter atomic.Pointer[io.Writer]
+)
+
+// SetLeakCheck enables or disables the leak check of players.
+//
+// When the leak check is enabled, a player records the stack trace where the player is created,
+// and the stack trace is printed to the standard error if the player is garbage-collected without being closed.
+// This is useful to find a missing call of Close.
+//
+// The leak check is applied to players created after SetLeakCheck is called.
+// Recording a stack trace is relatively expensive, so enable the leak check only for debugging.
+// When the leak check is disabled, there is no overhead.
+//
+// The leak check is disabled by default.
+//
+// SetLeakCheck is concurrent-safe.
+func SetLeakCheck(enabled bool) {
+	leakCheck.Store(enabled)
 }
 
 // NewPlayer creates a new player with the given stream.
@@ -329,7 +513,10 @@ func (c *Context) NewPlayer(src io.Reader) (*Player, error) {
 		return nil, err
 	}
 
-	p := &Player{pi}
+	p := &Player{p: pi}
+	if leakCheck.Load() {
+		p.stack = debug.Stack()
+	}
 
 	runtime.SetFinalizer(p, (*Player).finalize)
 
@@ -368,6 +555,13 @@ func NewPlayerFromBytes(context *Context, src []byte) *Player {
 func (p *Player) finalize() {
 	runtime.SetFinalizer(p, nil)
 	if !p.IsPlaying() {
+		if p.stack != nil && !p.p.isClosed() {
+			var w io.Writer = os.Stderr
+			if lw := leakCheckWriter.Load(); lw != nil {
+				w = *lw
+			}
+			_, _ = fmt.Fprintf(w, "audio: a Player was garbage-collected without Close. The Player was created at:\n%s", p.stack)
+		}
 		_ = p.Close()
 	}
 }
@@ -448,6 +642,50 @@ func (p *Player) SetVolume(volume float64) {
 	p.p.SetVolume(volume)
 }
 
+// Pan returns the current panning of this player [-1, 1].
+func (p *Player) Pan() float64 {
+	return p.p.Pan()
+}
+
+// SetPan sets the stereo panning of this player.
+// pan must be in between -1 (fully left) and 1 (fully right), and 0 means the center. SetPan panics otherwise.
+//
+// By default, a player is not panned.
+// Once SetPan is called, a constant-power pan law is applied to the player:
+// at the center, both channels are attenuated by about 3dB, and at -1, the right channel is silent.
+//
+// A new panning value takes effect after the data already buffered in the player is played.
+func (p *Player) SetPan(pan float64) {
+	if pan < -1 || pan > 1 {
+		panic(fmt.Sprintf("audio: pan must be in between -1 and 1 but %f", pan))
+	}
+	p.p.SetPan(pan)
+}
+
+// SetAnalyser attaches the analyser to this player.
+// If analyser is nil, the current analyser is detached.
+//
+// The analyser receives the same stream data as the player's output after panning, but before the volume is applied.
+// Note that the data is received when the player reads it, which is a little ahead of what is heard
+// by the size of the player's buffer.
+//
+// An analyser should not be attached to multiple players at the same time.
+func (p *Player) SetAnalyser(analyser *Analyser) {
+	p.p.SetAnalyser(analyser)
+}
+
+// SetFinishedCallback sets a function that is called once when the player finishes playing the source to its end.
+// If f is nil, the current callback is removed.
+//
+// f is called on the game's goroutine before Update in the tick after the player finishes playing.
+// f is not called when the player is paused or closed explicitly.
+// f is never called for a source that doesn't end, like an InfiniteLoop.
+//
+// f should not block, as this delays the game's update. f can call functions of players, e.g. to play the next clip.
+func (p *Player) SetFinishedCallback(f func()) {
+	p.p.SetFinishedCallback(f)
+}
+
 // SetBufferSize adjusts the buffer size of the player.
 // If 0 is specified, the default buffer size is used.
 // A small buffer size is useful if you want to play a real-time PCM for example.
@@ -497,3 +735,23 @@ func Resample(source io.ReadSeeker, size int64, from, to int) io.ReadSeeker {
 	}
 	return convert.NewResampling(source, size, from, to)
 }
+
+// ResampleReader converts the sample rate of the given stream on the fly.
+// from is the original sample rate.
+// to is the target sample rate.
+// channelCount is the number of the channels of the stream.
+//
+// As opposed to Resample, the source doesn't have to be seekable and its size doesn't have to be known.
+// This is useful to play a streaming source whose sample rate differs from the context's.
+//
+// The stream format must be signed 16bit little endian.
+// The samples are interpolated linearly. This is cheaper than Resample's windowed sinc,
+// but some aliasing might be audible when the sample rate is lowered.
+//
+// If the original sample rate equals to the new one, ResampleReader returns source as it is.
+func ResampleReader(source io.Reader, from, to int, channelCount int) io.Reader {
+	if from == to {
+		return source
+	}
+	return convert.NewStreamResampling(source, from, to, channelCount)
+}
@@ -16,8 +16,11 @@ package audio_test
 
 import (
 	"bytes"
+	"io"
 	"runtime"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/audio"
@@ -116,3 +119,244 @@ func TestPauseBeforeInit(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestPan(t *testing.T) {
+	const v = 10000
+
+	cases := []struct {
+		Pan   float64
+		Left  int16
+		Right int16
+	}{
+		{
+			Pan:   -1,
+			Left:  v,
+			Right: 0,
+		},
+		{
+			// -3dB at the center.
+			Pan:   0,
+			Left:  7071,
+			Right: 7071,
+		},
+		{
+			Pan:   1,
+			Left:  0,
+			Right: v,
+		},
+	}
+
+	for _, c := range cases {
+		src := make([]byte, 4*16)
+		for i := 0; i < len(src)/2; i++ {
+			x := int16(v)
+			src[2*i] = byte(x)
+			src[2*i+1] = byte(x >> 8)
+		}
+
+		// Read the stream byte by byte to check that samples split across reads are handled correctly.
+		s, err := audio.NewPannedStreamForTesting(iotest.OneByteReader(bytes.NewReader(src)), c.Pan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(src) {
+			t.Fatalf("pan: %f, len(got): %d, want: %d", c.Pan, len(got), len(src))
+		}
+		for i := 0; i < len(got)/4; i++ {
+			l := int16(got[4*i]) | int16(got[4*i+1])<<8
+			r := int16(got[4*i+2]) | int16(got[4*i+3])<<8
+			if l != c.Left || r != c.Right {
+				t.Errorf("pan: %f, sample %d: got: (%d, %d), want: (%d, %d)", c.Pan, i, l, r, c.Left, c.Right)
+			}
+		}
+	}
+}
+
+func TestMasterVolume(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p0 := context.NewPlayerFromBytes(make([]byte, 4))
+	p1 := context.NewPlayerFromBytes(make([]byte, 4))
+	p0.SetVolume(0.5)
+	p1.SetVolume(0.5)
+
+	p0.Play()
+	context.SetMasterVolume(0.5)
+	if got, want := context.MasterVolume(), 0.5; got != want {
+		t.Errorf("MasterVolume(): got: %f, want: %f", got, want)
+	}
+	if got, want := p0.Volume(), 0.5; got != want {
+		t.Errorf("Volume(): got: %f, want: %f", got, want)
+	}
+	if got, want := p0.UnderlyingVolumeForTesting(), 0.25; got != want {
+		t.Errorf("underlying volume of a playing player: got: %f, want: %f", got, want)
+	}
+
+	// A player that is not playing is updated when it starts playing.
+	p1.Play()
+	if got, want := p1.UnderlyingVolumeForTesting(), 0.25; got != want {
+		t.Errorf("underlying volume of a player started after SetMasterVolume: got: %f, want: %f", got, want)
+	}
+
+	p0.SetVolume(1)
+	if got, want := p0.UnderlyingVolumeForTesting(), 0.5; got != want {
+		t.Errorf("underlying volume after SetVolume: got: %f, want: %f", got, want)
+	}
+}
+
+func TestCrossfade(t *testing.T) {
+	setup()
+	defer teardown()
+
+	from := context.NewPlayerFromBytes(make([]byte, 4))
+	to := context.NewPlayerFromBytes(make([]byte, 4))
+	from.SetVolume(0.5)
+	to.SetVolume(0.8)
+
+	c := audio.Crossfade(from, to, 50*time.Millisecond)
+	if got, want := to.Volume(), 0.0; got != want {
+		t.Errorf("to.Volume() at the beginning: got: %f, want: %f", got, want)
+	}
+	if c.IsDone() {
+		t.Errorf("IsDone() at the beginning: got: true, want: false")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if !c.IsDone() {
+		t.Errorf("IsDone() after the duration: got: false, want: true")
+	}
+	if from.IsPlaying() {
+		t.Errorf("from.IsPlaying() after the duration: got: true, want: false")
+	}
+	if got, want := from.Volume(), 0.5; got != want {
+		t.Errorf("from.Volume() after the duration: got: %f, want: %f", got, want)
+	}
+	if got, want := to.Volume(), 0.8; got != want {
+		t.Errorf("to.Volume() after the duration: got: %f, want: %f", got, want)
+	}
+}
+
+func TestCrossfadeCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	from := context.NewPlayerFromBytes(make([]byte, 4))
+	to := context.NewPlayerFromBytes(make([]byte, 4))
+
+	c := audio.Crossfade(from, to, 50*time.Millisecond)
+	c.Cancel()
+
+	time.Sleep(100 * time.Millisecond)
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := from.Volume(), 1.0; got != want {
+		t.Errorf("from.Volume() after Cancel: got: %f, want: %f", got, want)
+	}
+	if got, want := to.Volume(), 0.0; got != want {
+		t.Errorf("to.Volume() after Cancel: got: %f, want: %f", got, want)
+	}
+}
+
+func TestFinishedCallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p := context.NewPlayerFromBytes(make([]byte, 4))
+	var count int
+	p.SetFinishedCallback(func() {
+		count++
+	})
+	p.Play()
+
+	for i := 0; i < 10; i++ {
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+		if count > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestFinishedCallbackAfterClose(t *testing.T) {
+	setup()
+	defer teardown()
+
+	r := &blockingReader{ch: make(chan struct{})}
+	defer close(r.ch)
+
+	p, err := context.NewPlayer(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	p.SetFinishedCallback(func() {
+		count++
+	})
+	p.Play()
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 0; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestLeakCheck(t *testing.T) {
+	setup()
+	defer teardown()
+
+	audio.SetLeakCheck(true)
+	defer audio.SetLeakCheck(false)
+	report, restore := audio.SetLeakCheckWriterForTesting()
+	defer restore()
+
+	// A closed player is not reported.
+	p := context.NewPlayerFromBytes(make([]byte, 4))
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	runtime.KeepAlive(p)
+	p = nil
+
+	// An unclosed player is reported.
+	context.NewPlayerFromBytes(make([]byte, 4))
+
+	for i := 0; i < 10; i++ {
+		runtime.GC()
+		if report() != "" {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	runtime.GC()
+	time.Sleep(10 * time.Millisecond)
+
+	got := report()
+	if !strings.Contains(got, "TestLeakCheck") {
+		t.Errorf("the report must include the stack trace of TestLeakCheck but: %q", got)
+	}
+	if n := strings.Count(got, "without Close"); n != 1 {
+		t.Errorf("the number of the reports: got: %d, want: 1", n)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"sync"
+	"time"
+)
+
+// Crossfader represents a running crossfade between two players.
+type Crossfader struct {
+	from       *Player
+	to         *Player
+	duration   time.Duration
+	fromVolume float64
+	toVolume   float64
+
+	stopwatch stopwatch
+	done      bool
+
+	m sync.Mutex
+}
+
+// Crossfade fades out from and fades in to over the duration d.
+//
+// The volume of from is lowered to 0 from its current volume, and the volume of to is raised from 0 to its current volume.
+// If to is not playing, Crossfade starts playing to.
+// When the crossfade finishes, from is paused and its volume is restored so that from can be played again as it was.
+//
+// The volumes are updated every tick. The crossfade doesn't proceed while the audio context is suspended.
+// Calling SetVolume of the players during a crossfade doesn't stop the crossfade. Use Cancel instead.
+//
+// from and to must be created by the same context.
+func Crossfade(from, to *Player, d time.Duration) *Crossfader {
+	c := &Crossfader{
+		from:       from,
+		to:         to,
+		duration:   d,
+		fromVolume: from.Volume(),
+		toVolume:   to.Volume(),
+	}
+
+	to.SetVolume(0)
+	if !to.IsPlaying() {
+		to.Play()
+	}
+	if d <= 0 {
+		c.update()
+		return c
+	}
+	c.stopwatch.start()
+	from.p.context.addCrossfader(c)
+	return c
+}
+
+// Cancel stops the crossfade.
+//
+// The players keep their current volumes and playing states.
+// Cancel does nothing when the crossfade is already finished or canceled.
+func (c *Crossfader) Cancel() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.done = true
+	c.stopwatch.stop()
+}
+
+// IsDone reports whether the crossfade is finished or canceled.
+func (c *Crossfader) IsDone() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.done
+}
+
+// update updates the volumes of the players and reports whether the crossfade is done.
+func (c *Crossfader) update() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.done {
+		return true
+	}
+
+	t := 1.0
+	if c.duration > 0 {
+		t = float64(c.stopwatch.current()) / float64(c.duration)
+	}
+	if t >= 1 {
+		c.from.Pause()
+		c.from.SetVolume(c.fromVolume)
+		c.to.SetVolume(c.toVolume)
+		c.done = true
+		c.stopwatch.stop()
+		return true
+	}
+
+	c.from.SetVolume(c.fromVolume * (1 - t))
+	c.to.SetVolume(c.toVolume * t)
+	return false
+}
+
+func (c *Crossfader) onContextSuspended() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.stopwatch.stop()
+}
+
+func (c *Crossfader) onContextResumed() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.done {
+		return
+	}
+	c.stopwatch.start()
+}
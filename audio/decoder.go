@@ -0,0 +1,143 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrUnknownFormat is returned by DecodeAny when the format of the source is not registered.
+var ErrUnknownFormat = errors.New("audio: unknown format")
+
+// DecodeFunc decodes src and returns a stream that is 16-bit little endian and 2 channels with the sample rate.
+type DecodeFunc func(sampleRate int, src io.Reader) (io.ReadSeeker, error)
+
+type decoder struct {
+	name   string
+	magic  string
+	decode DecodeFunc
+}
+
+var (
+	decoders  []decoder
+	decodersM sync.Mutex
+)
+
+// RegisterDecoder registers an audio format for DecodeAny.
+//
+// name is the name of the format, like "wav" or "mp3".
+// magic is the magic prefix that identifies the format's encoding.
+// The magic string can contain "?" wildcards that each match any one byte.
+//
+// RegisterDecoder is typically called in an init function of a decoder package.
+// The audio/wav, audio/mp3 and audio/vorbis packages register their formats when imported.
+//
+// RegisterDecoder is concurrent-safe.
+func RegisterDecoder(name, magic string, decode DecodeFunc) {
+	decodersM.Lock()
+	defer decodersM.Unlock()
+	decoders = append(decoders, decoder{
+		name:   name,
+		magic:  magic,
+		decode: decode,
+	})
+}
+
+func matchMagic(magic string, b []byte) bool {
+	if len(magic) != len(b) {
+		return false
+	}
+	for i, c := range b {
+		if magic[i] != c && magic[i] != '?' {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeAny decodes src in a registered format and returns a decoded stream with the sample rate.
+// DecodeAny also returns the format name used during format registration.
+//
+// The format is detected by the registered magic prefixes.
+// The bytes read to detect the format are not consumed: if src is an io.Seeker, src is seeked back to the
+// original position. Otherwise, src is wrapped with a buffered reader and the prefix is peeked.
+// Note that the resulting stream might not be seekable in the latter case.
+//
+// DecodeAny returns ErrUnknownFormat if no registered format matches.
+//
+// DecodeAny is concurrent-safe.
+func DecodeAny(sampleRate int, src io.Reader) (io.ReadSeeker, string, error) {
+	decodersM.Lock()
+	ds := make([]decoder, len(decoders))
+	copy(ds, decoders)
+	decodersM.Unlock()
+
+	var maxLen int
+	for _, d := range ds {
+		if maxLen < len(d.magic) {
+			maxLen = len(d.magic)
+		}
+	}
+
+	prefix, src, err := peekPrefix(src, maxLen)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, d := range ds {
+		if len(prefix) < len(d.magic) {
+			continue
+		}
+		if !matchMagic(d.magic, prefix[:len(d.magic)]) {
+			continue
+		}
+		s, err := d.decode(sampleRate, src)
+		if err != nil {
+			return nil, "", err
+		}
+		return s, d.name, nil
+	}
+	return nil, "", ErrUnknownFormat
+}
+
+// peekPrefix reads at most n bytes from the head of src without consuming them.
+// peekPrefix returns the reader that should be used instead of src.
+func peekPrefix(src io.Reader, n int) ([]byte, io.Reader, error) {
+	if s, ok := src.(io.ReadSeeker); ok {
+		pos, err := s.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, nil, err
+		}
+		buf := make([]byte, n)
+		m, err := io.ReadFull(s, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, nil, err
+		}
+		if _, err := s.Seek(pos, io.SeekStart); err != nil {
+			return nil, nil, err
+		}
+		return buf[:m], s, nil
+	}
+
+	r := bufio.NewReaderSize(src, n)
+	buf, err := r.Peek(n)
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	return buf, r, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+func init() {
+	audio.RegisterDecoder("test", "TE?T", func(sampleRate int, src io.Reader) (io.ReadSeeker, error) {
+		bs, err := io.ReadAll(src)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(bs), nil
+	})
+}
+
+func TestDecodeAny(t *testing.T) {
+	const src = "TEXT data"
+
+	for _, tc := range []struct {
+		name string
+		src  io.Reader
+	}{
+		{
+			name: "seeker",
+			src:  bytes.NewReader([]byte(src)),
+		},
+		{
+			name: "non-seeker",
+			src:  iotest.OneByteReader(bytes.NewReader([]byte(src))),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s, format, err := audio.DecodeAny(48000, tc.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := format, "test"; got != want {
+				t.Errorf("format: got: %q, want: %q", got, want)
+			}
+			bs, err := io.ReadAll(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The bytes read for sniffing must not be consumed.
+			if got, want := string(bs), src; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeAnyUnknownFormat(t *testing.T) {
+	for _, src := range []string{"", "TE", "UNKNOWN"} {
+		if _, _, err := audio.DecodeAny(48000, bytes.NewReader([]byte(src))); !errors.Is(err, audio.ErrUnknownFormat) {
+			t.Errorf("DecodeAny(%q): got: %v, want: %v", src, err, audio.ErrUnknownFormat)
+		}
+	}
+}
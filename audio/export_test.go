@@ -15,6 +15,7 @@
 package audio
 
 import (
+	"bytes"
 	"io"
 	"sync"
 )
@@ -151,6 +152,53 @@ func ResetContextForTesting() {
 	theContext = nil
 }
 
+func (p *Player) UnderlyingVolumeForTesting() float64 {
+	p.p.m.Lock()
+	defer p.p.m.Unlock()
+	if p.p.player == nil {
+		return 0
+	}
+	return p.p.player.Volume()
+}
+
+func NewPannedStreamForTesting(src io.Reader, pan float64) (io.Reader, error) {
+	s, err := newTimeStream(src, 44100)
+	if err != nil {
+		return nil, err
+	}
+	s.setPan(pan)
+	return s, nil
+}
+
 func (i *InfiniteLoop) SetNoBlendForTesting(value bool) {
 	i.noBlendForTesting = value
 }
+
+type syncBuffer struct {
+	buf bytes.Buffer
+	m   sync.Mutex
+}
+
+func (s *syncBuffer) Write(b []byte) (int, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.buf.Write(b)
+}
+
+func (s *syncBuffer) String() string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.buf.String()
+}
+
+// SetLeakCheckWriterForTesting replaces the destination of the leak reports.
+// SetLeakCheckWriterForTesting returns a function to get the reports, and a function to restore the original destination.
+func SetLeakCheckWriterForTesting() (report func() string, restore func()) {
+	b := &syncBuffer{}
+	orig := leakCheckWriter.Load()
+	var w io.Writer = b
+	leakCheckWriter.Store(&w)
+	return b.String, func() {
+		leakCheckWriter.Store(orig)
+	}
+}
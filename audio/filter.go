@@ -0,0 +1,187 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+)
+
+type filterType int
+
+const (
+	filterTypeLowPass filterType = iota
+	filterTypeHighPass
+)
+
+// Filter is a stream that applies a second-order (biquad) low-pass or high-pass filter to its source.
+//
+// The stream format must be 16-bit little endian and 2 channels, as well as the other streams for players.
+type Filter struct {
+	src        io.Reader
+	sampleRate int
+	typ        filterType
+	cutoff     float64
+
+	// b0, b1, b2, a1 and a2 are the coefficients normalized by a0.
+	b0, b1, b2 float64
+	a1, a2     float64
+
+	// x1, x2, y1 and y2 are the last inputs and outputs for each channel.
+	x1, x2 [channelCount]float64
+	y1, y2 [channelCount]float64
+
+	// rest is the bytes of an incomplete sample from the last Read, which is not filtered yet.
+	rest []byte
+
+	// filtered is the filtered bytes of a sample that didn't fit in the buffer of the last Read.
+	filtered []byte
+
+	// m is a mutex for this stream.
+	// Read is called from a different goroutine than SetCutoff.
+	m sync.Mutex
+}
+
+// NewLowPassFilter returns a stream that attenuates the frequencies of src higher than cutoffHz.
+// This is useful for e.g. an underwater effect.
+//
+// sampleRate is the sample rate of src, which is usually the context's sample rate.
+// cutoffHz must be positive and less than half of sampleRate. NewLowPassFilter panics otherwise.
+//
+// The returned stream is not seekable.
+func NewLowPassFilter(src io.Reader, cutoffHz float64, sampleRate int) *Filter {
+	return newFilter(src, filterTypeLowPass, cutoffHz, sampleRate)
+}
+
+// NewHighPassFilter returns a stream that attenuates the frequencies of src lower than cutoffHz.
+//
+// sampleRate is the sample rate of src, which is usually the context's sample rate.
+// cutoffHz must be positive and less than half of sampleRate. NewHighPassFilter panics otherwise.
+//
+// The returned stream is not seekable.
+func NewHighPassFilter(src io.Reader, cutoffHz float64, sampleRate int) *Filter {
+	return newFilter(src, filterTypeHighPass, cutoffHz, sampleRate)
+}
+
+func newFilter(src io.Reader, typ filterType, cutoffHz float64, sampleRate int) *Filter {
+	f := &Filter{
+		src:        src,
+		sampleRate: sampleRate,
+		typ:        typ,
+	}
+	f.SetCutoff(cutoffHz)
+	return f
+}
+
+// Cutoff returns the cutoff frequency in Hz.
+func (f *Filter) Cutoff() float64 {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return f.cutoff
+}
+
+// SetCutoff sets the cutoff frequency in Hz.
+// cutoffHz must be positive and less than half of the sample rate. SetCutoff panics otherwise.
+//
+// SetCutoff can be called while the stream is being played.
+// A new cutoff takes effect after the data already buffered in the player is played.
+func (f *Filter) SetCutoff(cutoffHz float64) {
+	if cutoffHz <= 0 || cutoffHz >= float64(f.sampleRate)/2 {
+		panic(fmt.Sprintf("audio: cutoffHz must be in between 0 and %d but %f", f.sampleRate/2, cutoffHz))
+	}
+
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	f.cutoff = cutoffHz
+
+	// See Robert Bristow-Johnson's Audio EQ Cookbook.
+	// Q is 1/sqrt(2), which makes the response maximally flat (Butterworth).
+	w0 := 2 * math.Pi * cutoffHz / float64(f.sampleRate)
+	cos := math.Cos(w0)
+	alpha := math.Sin(w0) / math.Sqrt2
+	a0 := 1 + alpha
+	switch f.typ {
+	case filterTypeLowPass:
+		f.b0 = (1 - cos) / 2 / a0
+		f.b1 = (1 - cos) / a0
+		f.b2 = (1 - cos) / 2 / a0
+	case filterTypeHighPass:
+		f.b0 = (1 + cos) / 2 / a0
+		f.b1 = -(1 + cos) / a0
+		f.b2 = (1 + cos) / 2 / a0
+	}
+	f.a1 = -2 * cos / a0
+	f.a2 = (1 - alpha) / a0
+}
+
+// Read is implementation of io.Reader's Read.
+func (f *Filter) Read(buf []byte) (int, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	// Serve the filtered bytes that didn't fit in the last buffer first.
+	if len(f.filtered) > 0 {
+		n := copy(buf, f.filtered)
+		f.filtered = f.filtered[n:]
+		return n, nil
+	}
+
+	// buf is too small to hold a whole sample. Filter one sample and keep the bytes that don't fit.
+	if len(buf) < bytesPerSampleInt16 {
+		var sample [bytesPerSampleInt16]byte
+		n, err := f.read(sample[:])
+		m := copy(buf, sample[:n])
+		f.filtered = append(f.filtered[:0], sample[m:n]...)
+		return m, err
+	}
+
+	return f.read(buf)
+}
+
+// read reads and filters whole samples into buf. buf must be able to hold at least one sample.
+func (f *Filter) read(buf []byte) (int, error) {
+	// Filtering requires whole samples. Use the incomplete sample from the last Read first.
+	// As rest is smaller than a sample, rest always fits in buf.
+	n := copy(buf, f.rest)
+	f.rest = f.rest[:0]
+	m, err := f.src.Read(buf[n:])
+	n += m
+
+	alignedN := n / bytesPerSampleInt16 * bytesPerSampleInt16
+	f.rest = append(f.rest, buf[alignedN:n]...)
+	for i := 0; i < alignedN; i += bytesPerSampleInt16 {
+		for ch := 0; ch < channelCount; ch++ {
+			idx := i + ch*bitDepthInBytesInt16
+			x := float64(int16(buf[idx]) | int16(buf[idx+1])<<8)
+			y := f.b0*x + f.b1*f.x1[ch] + f.b2*f.x2[ch] - f.a1*f.y1[ch] - f.a2*f.y2[ch]
+			f.x2[ch], f.x1[ch] = f.x1[ch], x
+			f.y2[ch], f.y1[ch] = f.y1[ch], y
+
+			if y > 1<<15-1 {
+				y = 1<<15 - 1
+			}
+			if y < -(1 << 15) {
+				y = -(1 << 15)
+			}
+			v := int16(y)
+			buf[idx] = byte(v)
+			buf[idx+1] = byte(v >> 8)
+		}
+	}
+	return alignedN, err
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+	"testing/iotest"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+const filterTestSampleRate = 44100
+
+func newSineStream(freq float64) []byte {
+	const amp = 10000
+
+	b := make([]byte, filterTestSampleRate/4*4) // 0.25 seconds
+	for i := 0; i < len(b)/4; i++ {
+		v := int16(amp * math.Sin(2*math.Pi*freq*float64(i)/filterTestSampleRate))
+		b[4*i] = byte(v)
+		b[4*i+1] = byte(v >> 8)
+		b[4*i+2] = byte(v)
+		b[4*i+3] = byte(v >> 8)
+	}
+	return b
+}
+
+// gain returns the ratio of the RMS of the filtered stream to the RMS of the source.
+// The first part of the stream is skipped as the filter's transient response.
+func gain(t *testing.T, src []byte, filter func(io.Reader) io.Reader) float64 {
+	got, err := io.ReadAll(filter(iotest.OneByteReader(bytes.NewReader(src))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(src) {
+		t.Fatalf("len(got): %d, want: %d", len(got), len(src))
+	}
+
+	rms := func(b []byte) float64 {
+		var sum float64
+		var n int
+		for i := len(b) / 2; i < len(b)/2*2; i += 2 {
+			v := float64(int16(b[i]) | int16(b[i+1])<<8)
+			sum += v * v
+			n++
+		}
+		return math.Sqrt(sum / float64(n))
+	}
+	return rms(got) / rms(src)
+}
+
+func TestLowPassFilter(t *testing.T) {
+	lowPass := func(r io.Reader) io.Reader {
+		return audio.NewLowPassFilter(r, 1000, filterTestSampleRate)
+	}
+	for _, freq := range []float64{50, 100, 200} {
+		if got := gain(t, newSineStream(freq), lowPass); got < 0.95 {
+			t.Errorf("gain at %f Hz: got: %f, want: >= 0.95", freq, got)
+		}
+	}
+	for _, freq := range []float64{5000, 10000, 15000} {
+		if got := gain(t, newSineStream(freq), lowPass); got > 0.05 {
+			t.Errorf("gain at %f Hz: got: %f, want: <= 0.05", freq, got)
+		}
+	}
+}
+
+func TestHighPassFilter(t *testing.T) {
+	highPass := func(r io.Reader) io.Reader {
+		return audio.NewHighPassFilter(r, 1000, filterTestSampleRate)
+	}
+	for _, freq := range []float64{50, 100} {
+		if got := gain(t, newSineStream(freq), highPass); got > 0.05 {
+			t.Errorf("gain at %f Hz: got: %f, want: <= 0.05", freq, got)
+		}
+	}
+	for _, freq := range []float64{5000, 10000, 15000} {
+		if got := gain(t, newSineStream(freq), highPass); got < 0.95 {
+			t.Errorf("gain at %f Hz: got: %f, want: >= 0.95", freq, got)
+		}
+	}
+}
+
+func TestFilterSetCutoff(t *testing.T) {
+	src := newSineStream(5000)
+	got := gain(t, src, func(r io.Reader) io.Reader {
+		f := audio.NewLowPassFilter(r, 1000, filterTestSampleRate)
+		f.SetCutoff(15000)
+		return f
+	})
+	if got < 0.95 {
+		t.Errorf("gain at 5000 Hz after SetCutoff(15000): got: %f, want: >= 0.95", got)
+	}
+}
+
+func TestFilterSmallBuffer(t *testing.T) {
+	src := newSineStream(1000)
+
+	want, err := io.ReadAll(audio.NewLowPassFilter(bytes.NewReader(src), 2000, filterTestSampleRate))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range []int{1, 2, 3, 5, 7} {
+		// Read with a buffer smaller than a sample or not aligned to samples, from a source returning odd sizes.
+		f := audio.NewLowPassFilter(iotest.HalfReader(bytes.NewReader(src)), 2000, filterTestSampleRate)
+		var got []byte
+		buf := make([]byte, size)
+		for {
+			n, err := f.Read(buf)
+			got = append(got, buf[:n]...)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("buffer size %d: the filtered stream doesn't match", size)
+		}
+	}
+}
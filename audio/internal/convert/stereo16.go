@@ -15,32 +15,45 @@
 package convert
 
 import (
+	"fmt"
 	"io"
 )
 
 type Stereo16 struct {
-	source io.ReadSeeker
-	mono   bool
-	eight  bool
-	buf    []byte
+	source          io.ReadSeeker
+	mono            bool
+	bitDepthInBytes int
+	buf             []byte
 }
 
-func NewStereo16(source io.ReadSeeker, mono, eight bool) *Stereo16 {
+// NewStereo16 returns a reader to convert the given source into 16bit little endian stereo.
+//
+// bitDepthInBytes must be 1 (unsigned 8bit), 2 (signed 16bit), 3 (signed 24bit) or 4 (signed 32bit).
+// The source must be little endian.
+func NewStereo16(source io.ReadSeeker, mono bool, bitDepthInBytes int) *Stereo16 {
+	switch bitDepthInBytes {
+	case 1, 2, 3, 4:
+	default:
+		panic(fmt.Sprintf("convert: bitDepthInBytes must be 1, 2, 3 or 4 but %d", bitDepthInBytes))
+	}
 	return &Stereo16{
-		source: source,
-		mono:   mono,
-		eight:  eight,
+		source:          source,
+		mono:            mono,
+		bitDepthInBytes: bitDepthInBytes,
 	}
 }
 
-func (s *Stereo16) Read(b []byte) (int, error) {
-	l := len(b)
+func (s *Stereo16) channelCount() int {
 	if s.mono {
-		l /= 2
-	}
-	if s.eight {
-		l /= 2
+		return 1
 	}
+	return 2
+}
+
+func (s *Stereo16) Read(b []byte) (int, error) {
+	// A frame is a set of samples for all the channels at one moment.
+	srcFrameSize := s.channelCount() * s.bitDepthInBytes
+	l := len(b) / 4 * srcFrameSize
 
 	if cap(s.buf) < l {
 		s.buf = make([]byte, l)
@@ -50,47 +63,45 @@ func (s *Stereo16) Read(b []byte) (int, error) {
 	if err != nil && err != io.EOF {
 		return 0, err
 	}
-	switch {
-	case s.mono && s.eight:
-		for i := 0; i < n; i++ {
-			v := int16(int(s.buf[i])*0x101 - (1 << 15))
-			b[4*i] = byte(v)
-			b[4*i+1] = byte(v >> 8)
-			b[4*i+2] = byte(v)
-			b[4*i+3] = byte(v >> 8)
-		}
-	case s.mono && !s.eight:
-		for i := 0; i < n/2; i++ {
-			b[4*i] = s.buf[2*i]
-			b[4*i+1] = s.buf[2*i+1]
-			b[4*i+2] = s.buf[2*i]
-			b[4*i+3] = s.buf[2*i+1]
-		}
-	case !s.mono && s.eight:
-		for i := 0; i < n/2; i++ {
-			v0 := int16(int(s.buf[2*i])*0x101 - (1 << 15))
-			v1 := int16(int(s.buf[2*i+1])*0x101 - (1 << 15))
-			b[4*i] = byte(v0)
-			b[4*i+1] = byte(v0 >> 8)
-			b[4*i+2] = byte(v1)
-			b[4*i+3] = byte(v1 >> 8)
+	// Complete the last frame if the source returns a partial frame.
+	if r := n % srcFrameSize; r != 0 && err == nil {
+		m, err2 := io.ReadFull(s.source, s.buf[n:n+srcFrameSize-r])
+		n += m
+		if err2 != nil {
+			if err2 != io.EOF && err2 != io.ErrUnexpectedEOF {
+				return 0, err2
+			}
+			err = io.EOF
 		}
 	}
-	if s.mono {
-		n *= 2
+
+	frames := n / srcFrameSize
+	for i := 0; i < frames; i++ {
+		v0 := s.sample(s.buf[i*srcFrameSize:])
+		v1 := v0
+		if !s.mono {
+			v1 = s.sample(s.buf[i*srcFrameSize+s.bitDepthInBytes:])
+		}
+		b[4*i] = byte(v0)
+		b[4*i+1] = byte(v0 >> 8)
+		b[4*i+2] = byte(v1)
+		b[4*i+3] = byte(v1 >> 8)
 	}
-	if s.eight {
-		n *= 2
+	return frames * 4, err
+}
+
+// sample returns the 16bit value of the first sample in buf.
+func (s *Stereo16) sample(buf []byte) int16 {
+	if s.bitDepthInBytes == 1 {
+		return int16(int(buf[0])*0x101 - (1 << 15))
 	}
-	return n, err
+	// For signed 16bit or more, the most significant 16 bits are used.
+	// This is equivalent to an arithmetic shift to the right.
+	d := s.bitDepthInBytes
+	return int16(buf[d-2]) | int16(buf[d-1])<<8
 }
 
 func (s *Stereo16) Seek(offset int64, whence int) (int64, error) {
-	if s.mono {
-		offset /= 2
-	}
-	if s.eight {
-		offset /= 2
-	}
+	offset = offset / 4 * int64(s.channelCount()*s.bitDepthInBytes)
 	return s.source.Seek(offset, whence)
 }
@@ -0,0 +1,127 @@
+// Copyright 2024 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/internal/convert"
+)
+
+func TestStereo16(t *testing.T) {
+	cases := []struct {
+		Name            string
+		In              []byte
+		Mono            bool
+		BitDepthInBytes int
+		Out             []int16
+	}{
+		{
+			Name:            "8bit mono",
+			In:              []byte{0x00, 0x80, 0xff},
+			Mono:            true,
+			BitDepthInBytes: 1,
+			Out:             []int16{-0x8000, -0x8000, 0x0080, 0x0080, 0x7fff, 0x7fff},
+		},
+		{
+			Name:            "8bit stereo",
+			In:              []byte{0x00, 0xff, 0x80, 0x80},
+			BitDepthInBytes: 1,
+			Out:             []int16{-0x8000, 0x7fff, 0x0080, 0x0080},
+		},
+		{
+			Name:            "16bit mono",
+			In:              []byte{0x00, 0x80, 0x00, 0x00, 0xff, 0x7f},
+			Mono:            true,
+			BitDepthInBytes: 2,
+			Out:             []int16{-0x8000, -0x8000, 0x0000, 0x0000, 0x7fff, 0x7fff},
+		},
+		{
+			Name:            "16bit stereo",
+			In:              []byte{0x00, 0x80, 0xff, 0x7f, 0xff, 0xff, 0x01, 0x00},
+			BitDepthInBytes: 2,
+			Out:             []int16{-0x8000, 0x7fff, -0x0001, 0x0001},
+		},
+		{
+			Name:            "24bit mono",
+			In:              []byte{0x00, 0x00, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7f},
+			Mono:            true,
+			BitDepthInBytes: 3,
+			Out:             []int16{-0x8000, -0x8000, 0x0000, 0x0000, 0x7fff, 0x7fff},
+		},
+		{
+			Name: "24bit stereo",
+			In: []byte{
+				0x00, 0x00, 0x80, 0xff, 0xff, 0x7f,
+				0xff, 0xff, 0xff, 0xff, 0x00, 0x00,
+				0x00, 0x01, 0x00, 0x00, 0xff, 0xff,
+			},
+			BitDepthInBytes: 3,
+			// Values smaller than 1/65536 of the full scale are truncated toward the negative infinity.
+			Out: []int16{-0x8000, 0x7fff, -0x0001, 0x0000, 0x0001, -0x0001},
+		},
+		{
+			Name:            "32bit mono",
+			In:              []byte{0x00, 0x00, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0x7f},
+			Mono:            true,
+			BitDepthInBytes: 4,
+			Out:             []int16{-0x8000, -0x8000, 0x0000, 0x0000, 0x7fff, 0x7fff},
+		},
+		{
+			Name: "32bit stereo",
+			In: []byte{
+				0x00, 0x00, 0x00, 0x80, 0xff, 0xff, 0xff, 0x7f,
+				0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x00,
+			},
+			BitDepthInBytes: 4,
+			Out:             []int16{-0x8000, 0x7fff, -0x0001, 0x0001},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			for _, size := range []int{4, 8, 4096} {
+				s := convert.NewStereo16(bytes.NewReader(c.In), c.Mono, c.BitDepthInBytes)
+				var got []byte
+				buf := make([]byte, size)
+				for {
+					n, err := s.Read(buf)
+					got = append(got, buf[:n]...)
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					if n == 0 {
+						t.Fatalf("Read returned 0 without an error")
+					}
+				}
+
+				want := make([]byte, 2*len(c.Out))
+				for i, v := range c.Out {
+					want[2*i] = byte(v)
+					want[2*i+1] = byte(v >> 8)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("buffer size: %d, got: %v, want: %v", size, got, want)
+				}
+			}
+		})
+	}
+}
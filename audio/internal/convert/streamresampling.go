@@ -0,0 +1,173 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+)
+
+// StreamResampling converts the sample rate of a signed 16bit little endian stream with linear interpolation.
+//
+// As opposed to Resampling, StreamResampling doesn't require the source to be seekable or its size to be known.
+type StreamResampling struct {
+	source       io.Reader
+	from         int64
+	to           int64
+	channelCount int
+
+	// buf is the bytes read from the source but not consumed yet.
+	buf    []byte
+	bufPos int
+
+	// prev and next are the source frames around the current output position.
+	// phase is the distance of the output position from prev in the unit of 1/to.
+	prev  []float64
+	next  []float64
+	phase int64
+
+	initialized bool
+	lastFrame   bool
+	done        bool
+	err         error
+}
+
+// NewStreamResampling returns a reader to convert the sample rate of the given source from from to to.
+func NewStreamResampling(source io.Reader, from, to int, channelCount int) *StreamResampling {
+	if from <= 0 || to <= 0 {
+		panic(fmt.Sprintf("convert: sample rates must be positive but %d and %d", from, to))
+	}
+	if channelCount <= 0 {
+		panic(fmt.Sprintf("convert: channelCount must be positive but %d", channelCount))
+	}
+	d := gcd(int64(from), int64(to))
+	return &StreamResampling{
+		source:       source,
+		from:         int64(from) / d,
+		to:           int64(to) / d,
+		channelCount: channelCount,
+		prev:         make([]float64, channelCount),
+		next:         make([]float64, channelCount),
+	}
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func (r *StreamResampling) frameSize() int {
+	return 2 * r.channelCount
+}
+
+// readFrame reads one frame from the source into dst.
+// readFrame returns false when the source reaches its end.
+func (r *StreamResampling) readFrame(dst []float64) (bool, error) {
+	const bufferFrameCount = 4096
+
+	frameSize := r.frameSize()
+	for len(r.buf)-r.bufPos < frameSize {
+		if r.err != nil {
+			return false, r.err
+		}
+		if r.buf == nil {
+			r.buf = make([]byte, 0, bufferFrameCount*frameSize)
+		}
+		// Move the incomplete frame to the head and fill the rest.
+		n := copy(r.buf[:cap(r.buf)], r.buf[r.bufPos:])
+		r.buf = r.buf[:n]
+		r.bufPos = 0
+		m, err := r.source.Read(r.buf[n:cap(r.buf)])
+		r.buf = r.buf[:n+m]
+		if err != nil {
+			r.err = err
+		}
+	}
+	b := r.buf[r.bufPos : r.bufPos+frameSize]
+	for i := range dst {
+		dst[i] = float64(int16(b[2*i]) | int16(b[2*i+1])<<8)
+	}
+	r.bufPos += frameSize
+	return true, nil
+}
+
+func (r *StreamResampling) advance() error {
+	if r.lastFrame {
+		r.done = true
+		return nil
+	}
+	r.prev, r.next = r.next, r.prev
+	ok, err := r.readFrame(r.next)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if !ok {
+		copy(r.next, r.prev)
+		r.lastFrame = true
+	}
+	return nil
+}
+
+func (r *StreamResampling) Read(b []byte) (int, error) {
+	if !r.initialized {
+		ok, err := r.readFrame(r.prev)
+		if !ok {
+			return 0, err
+		}
+		ok, err = r.readFrame(r.next)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		if !ok {
+			copy(r.next, r.prev)
+			r.lastFrame = true
+		}
+		r.initialized = true
+	}
+
+	frameSize := r.frameSize()
+	var n int
+	for n+frameSize <= len(b) && !r.done {
+		t := float64(r.phase) / float64(r.to)
+		for i := 0; i < r.channelCount; i++ {
+			v := r.prev[i] + (r.next[i]-r.prev[i])*t
+			if v > 1<<15-1 {
+				v = 1<<15 - 1
+			}
+			if v < -(1 << 15) {
+				v = -(1 << 15)
+			}
+			v16 := int16(v)
+			b[n+2*i] = byte(v16)
+			b[n+2*i+1] = byte(v16 >> 8)
+		}
+		n += frameSize
+
+		r.phase += r.from
+		for r.phase >= r.to && !r.done {
+			r.phase -= r.to
+			if err := r.advance(); err != nil {
+				return n, err
+			}
+		}
+	}
+
+	if n == 0 && r.done {
+		return 0, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+	"testing/iotest"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/internal/convert"
+)
+
+func newSineBytes(freq float64, sampleRate int, channelCount int) []byte {
+	b := make([]byte, sampleRate*2*channelCount) // 1 second
+	for i := 0; i < sampleRate; i++ {
+		v16 := int16(0.5 * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)) * (1<<15 - 1))
+		for j := 0; j < channelCount; j++ {
+			b[2*(i*channelCount+j)] = byte(v16)
+			b[2*(i*channelCount+j)+1] = byte(v16 >> 8)
+		}
+	}
+	return b
+}
+
+// frequency estimates the frequency of the first channel by counting rising zero crossings.
+func frequency(b []byte, sampleRate int, channelCount int) float64 {
+	frameSize := 2 * channelCount
+	var crossings int
+	var first, last int
+	prev := int16(b[0]) | int16(b[1])<<8
+	for i := 1; i < len(b)/frameSize; i++ {
+		v := int16(b[i*frameSize]) | int16(b[i*frameSize+1])<<8
+		if prev < 0 && v >= 0 {
+			if crossings == 0 {
+				first = i
+			}
+			last = i
+			crossings++
+		}
+		prev = v
+	}
+	return float64(crossings-1) * float64(sampleRate) / float64(last-first)
+}
+
+func TestStreamResampling(t *testing.T) {
+	const freq = 440
+
+	cases := []struct {
+		In           int
+		Out          int
+		ChannelCount int
+	}{
+		{
+			In:           44100,
+			Out:          48000,
+			ChannelCount: 2,
+		},
+		{
+			In:           48000,
+			Out:          44100,
+			ChannelCount: 2,
+		},
+		{
+			In:           22050,
+			Out:          48000,
+			ChannelCount: 1,
+		},
+	}
+	for _, c := range cases {
+		inB := newSineBytes(freq, c.In, c.ChannelCount)
+		// Read the source one byte at a time to test incomplete frames.
+		r := convert.NewStreamResampling(iotest.OneByteReader(bytes.NewReader(inB)), c.In, c.Out, c.ChannelCount)
+		gotB, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := len(gotB)/(2*c.ChannelCount), c.Out; math.Abs(float64(got-want)) > 1 {
+			t.Errorf("%d -> %d: frame count: got: %d, want: %d", c.In, c.Out, got, want)
+		}
+		if got := frequency(gotB, c.Out, c.ChannelCount); math.Abs(got-freq) > 0.5 {
+			t.Errorf("%d -> %d: frequency: got: %f, want: %f", c.In, c.Out, got, float64(freq))
+		}
+	}
+}
+
+func TestStreamResamplingEmpty(t *testing.T) {
+	r := convert.NewStreamResampling(bytes.NewReader(nil), 44100, 48000, 2)
+	gotB, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotB) != 0 {
+		t.Errorf("len(gotB): got: %d, want: 0", len(gotB))
+	}
+}
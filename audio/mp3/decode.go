@@ -122,3 +122,17 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 func Decode(context *audio.Context, src io.Reader) (*Stream, error) {
 	return DecodeWithSampleRate(context.SampleRate(), src)
 }
+
+func init() {
+	for _, magic := range []string{"ID3", "\xff\xfb", "\xff\xfa", "\xff\xf3", "\xff\xf2"} {
+		audio.RegisterDecoder("mp3", magic, decodeAny)
+	}
+}
+
+func decodeAny(sampleRate int, src io.Reader) (io.ReadSeeker, error) {
+	s, err := DecodeWithSampleRate(sampleRate, src)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -16,6 +16,7 @@ package audio
 
 import (
 	"io"
+	"math"
 	"runtime"
 	"sync"
 	"time"
@@ -46,15 +47,17 @@ type context interface {
 type playerFactory struct {
 	context    context
 	sampleRate int
+	bufferSize time.Duration
 
 	m sync.Mutex
 }
 
 var driverForTesting context
 
-func newPlayerFactory(sampleRate int) *playerFactory {
+func newPlayerFactory(sampleRate int, bufferSize time.Duration) *playerFactory {
 	f := &playerFactory{
 		sampleRate: sampleRate,
+		bufferSize: bufferSize,
 	}
 	if driverForTesting != nil {
 		f.context = driverForTesting
@@ -82,6 +85,21 @@ type playerImpl struct {
 	// stopwatch is a stopwatch to measure the time duration during the player position doesn't change while its playing.
 	stopwatch stopwatch
 
+	// volume is the volume of this player without the master volume of the context.
+	volume float64
+
+	// pan is the panning value in [-1, 1].
+	// pan is applied only when panning is true.
+	pan     float64
+	panning bool
+
+	analyser *Analyser
+
+	// finishedCallback is called when the player finishes playing the source to its end.
+	finishedCallback func()
+
+	closed bool
+
 	m sync.Mutex
 }
 
@@ -94,6 +112,7 @@ func (f *playerFactory) newPlayer(context *Context, src io.Reader) (*playerImpl,
 		context:     context,
 		factory:     f,
 		lastSamples: -1,
+		volume:      1,
 	}
 	runtime.SetFinalizer(p, (*playerImpl).Close)
 	return p, nil
@@ -137,7 +156,7 @@ func (f *playerFactory) initContextIfNeeded() (<-chan struct{}, error) {
 		return nil, nil
 	}
 
-	c, ready, err := newContext(f.sampleRate)
+	c, ready, err := newContext(f.sampleRate, f.bufferSize)
 	if err != nil {
 		return nil, err
 	}
@@ -167,10 +186,15 @@ func (p *playerImpl) ensurePlayer() error {
 		if err != nil {
 			return err
 		}
+		if p.panning {
+			s.setPan(p.pan)
+		}
+		s.setAnalyser(p.analyser)
 		p.stream = s
 	}
 	if p.player == nil {
 		p.player = p.factory.context.NewPlayer(p.stream)
+		p.player.SetVolume(p.volume * p.context.MasterVolume())
 		if p.initBufferSize != 0 {
 			p.player.SetBufferSize(p.initBufferSize)
 			p.initBufferSize = 0
@@ -190,6 +214,8 @@ func (p *playerImpl) Play() {
 	if p.player.IsPlaying() {
 		return
 	}
+	// The master volume might be changed while this player is not playing.
+	p.player.SetVolume(p.volume * p.context.MasterVolume())
 	p.player.Play()
 	p.context.addPlayingPlayer(p)
 	p.stopwatch.start()
@@ -227,29 +253,90 @@ func (p *playerImpl) isPlaying() bool {
 func (p *playerImpl) Volume() float64 {
 	p.m.Lock()
 	defer p.m.Unlock()
+	return p.volume
+}
+
+func (p *playerImpl) SetVolume(volume float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
 
 	if err := p.ensurePlayer(); err != nil {
 		p.context.setError(err)
-		return 0
+		return
 	}
-	return p.player.Volume()
+	p.volume = volume
+	p.player.SetVolume(p.volume * p.context.MasterVolume())
 }
 
-func (p *playerImpl) SetVolume(volume float64) {
+func (p *playerImpl) updateVolume() {
 	p.m.Lock()
 	defer p.m.Unlock()
 
-	if err := p.ensurePlayer(); err != nil {
-		p.context.setError(err)
+	if p.player == nil {
 		return
 	}
-	p.player.SetVolume(volume)
+	p.player.SetVolume(p.volume * p.context.MasterVolume())
+}
+
+func (p *playerImpl) Pan() float64 {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.pan
+}
+
+func (p *playerImpl) SetPan(pan float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.pan = pan
+	p.panning = true
+	if p.stream != nil {
+		p.stream.setPan(pan)
+	}
+}
+
+func (p *playerImpl) SetAnalyser(analyser *Analyser) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.analyser = analyser
+	if p.stream != nil {
+		p.stream.setAnalyser(analyser)
+	}
+}
+
+func (p *playerImpl) SetFinishedCallback(f func()) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.finishedCallback = f
+}
+
+// finishedCallbackIfEnded returns the finished callback if the player stopped by itself.
+// This must be called only for a player that was playing at the last tick.
+func (p *playerImpl) finishedCallbackIfEnded() func() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	// A closed player doesn't have the underlying player.
+	if p.player == nil {
+		return nil
+	}
+	if p.player.IsPlaying() {
+		return nil
+	}
+	// Pause removes the player from the playing players with the player's lock.
+	// A player that is still in the playing players is not paused explicitly, and reached the end.
+	if !p.context.isPlayingPlayer(p) {
+		return nil
+	}
+	return p.finishedCallback
 }
 
 func (p *playerImpl) Close() error {
 	p.m.Lock()
 	defer p.m.Unlock()
 	runtime.SetFinalizer(p, nil)
+	p.closed = true
 
 	if p.player != nil {
 		defer func() {
@@ -262,6 +349,12 @@ func (p *playerImpl) Close() error {
 	return nil
 }
 
+func (p *playerImpl) isClosed() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.closed
+}
+
 func (p *playerImpl) Position() time.Duration {
 	p.m.Lock()
 	defer p.m.Unlock()
@@ -385,6 +478,19 @@ type timeStream struct {
 	sampleRate int
 	pos        int64
 
+	// leftGain and rightGain are the gains for the channels.
+	// These are used only when panning is true.
+	leftGain  float64
+	rightGain float64
+	panning   bool
+
+	// rest is the bytes of an incomplete sample from the last Read.
+	// rest is used only when panning is true.
+	rest []byte
+
+	// analyser is an analyser to which the read bytes are written.
+	analyser *Analyser
+
 	// m is a mutex for this stream.
 	// All the exported functions are protected by this mutex as Read can be read from a different goroutine than Seek.
 	m sync.Mutex
@@ -410,9 +516,54 @@ func (s *timeStream) Read(buf []byte) (int, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	n, err := s.r.Read(buf)
-	s.pos += int64(n)
-	return n, err
+	if !s.panning {
+		n, err := s.r.Read(buf)
+		s.pos += int64(n)
+		if s.analyser != nil {
+			_, _ = s.analyser.Write(buf[:n])
+		}
+		return n, err
+	}
+
+	// Panning requires whole samples. Use the incomplete sample from the last Read first.
+	n := copy(buf, s.rest)
+	s.rest = s.rest[n:]
+	m, err := s.r.Read(buf[n:])
+	n += m
+
+	alignedN := n / bytesPerSampleInt16 * bytesPerSampleInt16
+	s.rest = append(s.rest, buf[alignedN:n]...)
+	for i := 0; i < alignedN; i += bytesPerSampleInt16 {
+		l := int16(float64(int16(buf[i])|int16(buf[i+1])<<8) * s.leftGain)
+		r := int16(float64(int16(buf[i+2])|int16(buf[i+3])<<8) * s.rightGain)
+		buf[i] = byte(l)
+		buf[i+1] = byte(l >> 8)
+		buf[i+2] = byte(r)
+		buf[i+3] = byte(r >> 8)
+	}
+	s.pos += int64(alignedN)
+	if s.analyser != nil {
+		_, _ = s.analyser.Write(buf[:alignedN])
+	}
+	return alignedN, err
+}
+
+func (s *timeStream) setPan(pan float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	// Use the constant-power pan law so that the perceived loudness doesn't change while the sound moves.
+	// At the center, each channel is attenuated by about 3dB.
+	theta := (pan + 1) * math.Pi / 4
+	s.leftGain = math.Cos(theta)
+	s.rightGain = math.Sin(theta)
+	s.panning = true
+}
+
+func (s *timeStream) setAnalyser(analyser *Analyser) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.analyser = analyser
 }
 
 func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
@@ -430,6 +581,7 @@ func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	}
 
 	s.pos = pos
+	s.rest = s.rest[:0]
 	return pos, nil
 }
 
@@ -0,0 +1,181 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"math"
+	"sync"
+)
+
+// SpatializerOptions represents options for NewSpatializer.
+type SpatializerOptions struct {
+	// ReferenceDistance is the distance at which a source is heard at its own volume.
+	// A source nearer than ReferenceDistance is not louder than that.
+	//
+	// The default (zero) value means 1.
+	ReferenceDistance float64
+
+	// Rolloff is how fast the volume decreases with the distance.
+	// With 1, the volume is halved at twice the reference distance.
+	//
+	// The default (zero) value means 1.
+	Rolloff float64
+
+	// MaxDistance is the distance beyond which a source is paused.
+	// A source paused by the spatializer is played again when it comes within MaxDistance.
+	//
+	// The default (zero) value means that sources are never paused.
+	MaxDistance float64
+}
+
+// Spatializer controls the volumes and the pannings of players by the positions of the players and a listener.
+//
+// A Spatializer uses the inverse distance model:
+//
+//	volume = ReferenceDistance / (ReferenceDistance + Rolloff * (distance - ReferenceDistance))
+//
+// The panning is the lateral component of the direction from the listener to the source:
+// a source at +X is fully right and a source at -X is fully left.
+// The listener is assumed to face -Z with +Y up, so for a 2D game, specify 0 as z.
+type Spatializer struct {
+	referenceDistance float64
+	rolloff           float64
+	maxDistance       float64
+
+	listenerX float64
+	listenerY float64
+	listenerZ float64
+
+	sources map[*Player]*spatialSource
+
+	m sync.Mutex
+}
+
+type spatialSource struct {
+	x, y, z float64
+
+	// volume is the volume of the player when it was added to the spatializer.
+	volume float64
+
+	// paused reports whether the player is paused by the spatializer.
+	paused bool
+}
+
+// NewSpatializer creates a new Spatializer.
+//
+// If options is nil, the default setting is used.
+func NewSpatializer(options *SpatializerOptions) *Spatializer {
+	s := &Spatializer{
+		referenceDistance: 1,
+		rolloff:           1,
+		sources:           map[*Player]*spatialSource{},
+	}
+	if options != nil {
+		if options.ReferenceDistance > 0 {
+			s.referenceDistance = options.ReferenceDistance
+		}
+		if options.Rolloff > 0 {
+			s.rolloff = options.Rolloff
+		}
+		s.maxDistance = options.MaxDistance
+	}
+	return s
+}
+
+// SetListener sets the position of the listener, and updates all the players in the spatializer.
+func (s *Spatializer) SetListener(x, y, z float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.listenerX = x
+	s.listenerY = y
+	s.listenerZ = z
+	for p, src := range s.sources {
+		s.update(p, src)
+	}
+}
+
+// SetSourcePosition sets the position of the player, and updates the player's volume and panning.
+//
+// If the player is not in the spatializer yet, SetSourcePosition adds it.
+// The player's current volume is used as the volume at the reference distance.
+// After a player is added, calling SetVolume or SetPan of the player directly has no effect
+// until the next update by the spatializer.
+func (s *Spatializer) SetSourcePosition(player *Player, x, y, z float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	src, ok := s.sources[player]
+	if !ok {
+		src = &spatialSource{
+			volume: player.Volume(),
+		}
+		s.sources[player] = src
+	}
+	src.x = x
+	src.y = y
+	src.z = z
+	s.update(player, src)
+}
+
+// RemoveSource removes the player from the spatializer.
+//
+// The player's volume is restored to the volume when it was added, and the player is centered.
+// If the player is paused by the spatializer, the player remains paused.
+func (s *Spatializer) RemoveSource(player *Player) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	src, ok := s.sources[player]
+	if !ok {
+		return
+	}
+	delete(s.sources, player)
+	player.SetVolume(src.volume)
+	player.SetPan(0)
+}
+
+func (s *Spatializer) update(player *Player, src *spatialSource) {
+	dx := src.x - s.listenerX
+	dy := src.y - s.listenerY
+	dz := src.z - s.listenerZ
+	d := math.Sqrt(dx*dx + dy*dy + dz*dz)
+
+	if s.maxDistance > 0 {
+		if d > s.maxDistance {
+			if player.IsPlaying() {
+				player.Pause()
+				src.paused = true
+			}
+			return
+		}
+		if src.paused {
+			player.Play()
+			src.paused = false
+		}
+	}
+
+	gain := 1.0
+	if d > s.referenceDistance {
+		gain = s.referenceDistance / (s.referenceDistance + s.rolloff*(d-s.referenceDistance))
+	}
+	player.SetVolume(src.volume * gain)
+
+	var pan float64
+	if d > 0 {
+		pan = math.Max(-1, math.Min(1, dx/d))
+	}
+	player.SetPan(pan)
+}
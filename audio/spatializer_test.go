@@ -0,0 +1,85 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// blockingReader is a reader that never returns, to keep a player playing.
+type blockingReader struct {
+	ch chan struct{}
+}
+
+func (b *blockingReader) Read(buf []byte) (int, error) {
+	<-b.ch
+	return 0, io.EOF
+}
+
+func TestSpatializer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	r := &blockingReader{ch: make(chan struct{})}
+	defer close(r.ch)
+
+	p, err := context.NewPlayer(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SetVolume(0.5)
+	p.Play()
+
+	s := audio.NewSpatializer(&audio.SpatializerOptions{
+		ReferenceDistance: 1,
+		Rolloff:           1,
+		MaxDistance:       100,
+	})
+
+	s.SetSourcePosition(p, 0.5, 0, 0)
+	if got, want := p.Volume(), 0.5; got != want {
+		t.Errorf("Volume() within the reference distance: got: %f, want: %f", got, want)
+	}
+
+	s.SetSourcePosition(p, 4, 0, 0)
+	if got, want := p.Volume(), 0.5/4; got != want {
+		t.Errorf("Volume() at the distance 4: got: %f, want: %f", got, want)
+	}
+	if got, want := p.Pan(), 1.0; got != want {
+		t.Errorf("Pan() at +X: got: %f, want: %f", got, want)
+	}
+
+	s.SetListener(8, 0, 0)
+	if got, want := p.Pan(), -1.0; got != want {
+		t.Errorf("Pan() after moving the listener: got: %f, want: %f", got, want)
+	}
+
+	s.SetSourcePosition(p, 200, 0, 0)
+	if p.IsPlaying() {
+		t.Errorf("IsPlaying() beyond the max distance: got: true, want: false")
+	}
+	s.SetSourcePosition(p, 10, 0, 0)
+	if !p.IsPlaying() {
+		t.Errorf("IsPlaying() within the max distance again: got: false, want: true")
+	}
+
+	s.RemoveSource(p)
+	if got, want := p.Volume(), 0.5; got != want {
+		t.Errorf("Volume() after RemoveSource: got: %f, want: %f", got, want)
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	defaultGrainSize = 50 * time.Millisecond
+	minGrainSize     = 10 * time.Millisecond
+	maxGrainSize     = 200 * time.Millisecond
+
+	// minGrainFrames is the minimum number of frames in a grain, which matters only for very low sample rates.
+	minGrainFrames = 8
+)
+
+// TimePitchOptions represents options for NewTimePitch.
+type TimePitchOptions struct {
+	// GrainSize is the length of the grains that the source is split into.
+	//
+	// GrainSize is the tradeoff between quality and latency.
+	// Longer grains keep the pitch of low and tonal sounds more stable, but smear transients like drums
+	// and delay a new pitch or speed by up to one grain.
+	// Shorter grains react faster and keep transients sharp, but low sounds might become rough.
+	//
+	// The default (zero) value means 50ms. A non-zero GrainSize is clamped to [10ms, 200ms].
+	GrainSize time.Duration
+}
+
+// TimePitch is a stream that changes the pitch and the playback speed of its source independently.
+//
+// TimePitch uses WSOLA (waveform similarity overlap-add): the source is split into overlapping grains,
+// and each grain is placed where its waveform is the most similar to the previous grain.
+// The pitch is changed by resampling each grain.
+//
+// The CPU cost is proportional to the number of output samples and the grain size.
+// In addition to resampling, the similarity search evaluates about GrainSize × sampleRate / 32 source samples
+// per output sample, e.g. about 70 with the default grain size at 44100 Hz.
+// This is usually small enough for a few sound effects, but consider preprocessing long music offline,
+// or use a shorter GrainSize.
+//
+// The stream format must be 16-bit little endian and 2 channels, as well as the other streams for players.
+type TimePitch struct {
+	src        io.Reader
+	grainSize  int
+	hop        int
+	searchSize int
+
+	pitch float64
+	speed float64
+
+	// in is the interleaved source samples. inStart is the index of the first frame of in in the source.
+	in      []float64
+	inStart int64
+	inRest  []byte
+	inBuf   []byte
+	inEOF   bool
+	err     error
+
+	// grainPos is the nominal position of the next grain in the source in frames.
+	grainPos float64
+	// prevPos is the actual position of the last grain in the source in frames.
+	prevPos float64
+	// prevStep is the resampling step of the last grain.
+	prevStep float64
+	// started reports whether the first grain is processed.
+	started bool
+
+	window []float64
+	accum  []float64
+
+	// ready is the output bytes that are not read yet.
+	ready    []byte
+	readyPos int
+	done     bool
+
+	m sync.Mutex
+}
+
+// NewTimePitch creates a new TimePitch stream with the given source and the sample rate of the source.
+//
+// sampleRate must be positive. NewTimePitch panics otherwise.
+//
+// If options is nil, the default setting is used.
+//
+// The returned stream is not seekable.
+func NewTimePitch(src io.Reader, sampleRate int, options *TimePitchOptions) *TimePitch {
+	if sampleRate <= 0 {
+		panic(fmt.Sprintf("audio: sampleRate must be positive but %d", sampleRate))
+	}
+
+	grainSize := defaultGrainSize
+	if options != nil && options.GrainSize != 0 {
+		grainSize = options.GrainSize
+		if grainSize < minGrainSize {
+			grainSize = minGrainSize
+		}
+		if grainSize > maxGrainSize {
+			grainSize = maxGrainSize
+		}
+	}
+
+	// The grain size must be even so that the Hann windows with a half hop sum to 1.
+	n := int(int64(grainSize)*int64(sampleRate)/int64(time.Second)) / 2 * 2
+	// A grain must have at least one frame in its hop to proceed.
+	if n < minGrainFrames {
+		n = minGrainFrames
+	}
+	t := &TimePitch{
+		src:        src,
+		grainSize:  n,
+		hop:        n / 2,
+		searchSize: n / 4,
+		pitch:      1,
+		speed:      1,
+		window:     make([]float64, n),
+		accum:      make([]float64, n*channelCount),
+		ready:      make([]byte, 0, n/2*bytesPerSampleInt16),
+	}
+	for i := range t.window {
+		t.window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))
+	}
+	return t
+}
+
+// Pitch returns the pitch shift in semitones.
+func (t *TimePitch) Pitch() float64 {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return 12 * math.Log2(t.pitch)
+}
+
+// SetPitch sets the pitch shift in semitones without changing the playback speed.
+// For example, 12 raises the pitch by an octave, and -12 lowers it by an octave.
+//
+// semitones must be in between -24 and 24. SetPitch panics otherwise.
+//
+// The default value is 0.
+func (t *TimePitch) SetPitch(semitones float64) {
+	if semitones < -24 || semitones > 24 {
+		panic(fmt.Sprintf("audio: semitones must be in between -24 and 24 but %f", semitones))
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.pitch = math.Pow(2, semitones/12)
+}
+
+// Speed returns the playback speed factor.
+func (t *TimePitch) Speed() float64 {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.speed
+}
+
+// SetSpeed sets the playback speed factor without changing the pitch.
+// For example, 2 plays the source twice as fast, and 0.5 plays it at half speed.
+//
+// factor must be in between 0.25 and 4. SetSpeed panics otherwise.
+//
+// The default value is 1.
+func (t *TimePitch) SetSpeed(factor float64) {
+	if factor < 0.25 || factor > 4 {
+		panic(fmt.Sprintf("audio: factor must be in between 0.25 and 4 but %f", factor))
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.speed = factor
+}
+
+// Read is implementation of io.Reader's Read.
+func (t *TimePitch) Read(buf []byte) (int, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	for t.readyPos == len(t.ready) {
+		if t.done {
+			return 0, io.EOF
+		}
+		if err := t.processGrain(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(buf, t.ready[t.readyPos:])
+	t.readyPos += n
+	return n, nil
+}
+
+// fill reads the source until the source frame at index end is available or the source reaches its end.
+func (t *TimePitch) fill(end int64) error {
+	for !t.inEOF && t.inStart+int64(len(t.in)/channelCount) <= end {
+		if t.err != nil {
+			return t.err
+		}
+		if t.inBuf == nil {
+			t.inBuf = make([]byte, t.grainSize*bytesPerSampleInt16)
+		}
+		n := copy(t.inBuf, t.inRest)
+		m, err := t.src.Read(t.inBuf[n:])
+		n += m
+		alignedN := n / bytesPerSampleInt16 * bytesPerSampleInt16
+		t.inRest = append(t.inRest[:0], t.inBuf[alignedN:n]...)
+		for i := 0; i < alignedN; i += bitDepthInBytesInt16 {
+			t.in = append(t.in, float64(int16(t.inBuf[i])|int16(t.inBuf[i+1])<<8))
+		}
+		if err == io.EOF {
+			t.inEOF = true
+			break
+		}
+		if err != nil {
+			t.err = err
+			return err
+		}
+	}
+	return nil
+}
+
+// at returns the linearly interpolated source sample at the frame position pos.
+func (t *TimePitch) at(pos float64, ch int) float64 {
+	i := int64(math.Floor(pos))
+	f := pos - float64(i)
+	get := func(i int64) float64 {
+		idx := (i - t.inStart) * channelCount
+		if idx < 0 || idx >= int64(len(t.in)) {
+			return 0
+		}
+		return t.in[idx+int64(ch)]
+	}
+	v0 := get(i)
+	if f == 0 {
+		return v0
+	}
+	return v0 + (get(i+1)-v0)*f
+}
+
+// bestOffset returns the offset of the grain position in [-searchSize, searchSize]
+// whose waveform is the most similar to the continuation of the previous grain.
+func (t *TimePitch) bestOffset(pos float64, step float64) float64 {
+	if !t.started {
+		return 0
+	}
+
+	// Compare the mono waveforms sparsely to keep the cost low.
+	const stride = 8
+	const offsetStride = 2
+
+	natural := t.prevPos + float64(t.hop)*t.prevStep
+	bestCorr := math.Inf(-1)
+	var best float64
+	for i := 0; i <= 2*t.searchSize/offsetStride; i++ {
+		// Search from the nominal position outwards so that ties keep the nominal position.
+		d := (i + 1) / 2 * offsetStride
+		if i%2 == 1 {
+			d = -d
+		}
+		p := pos + float64(d)
+		if p < float64(t.inStart) {
+			continue
+		}
+		var corr, energy float64
+		for j := 0; j < t.hop; j += stride {
+			a := t.at(natural+float64(j)*t.prevStep, 0) + t.at(natural+float64(j)*t.prevStep, 1)
+			b := t.at(p+float64(j)*step, 0) + t.at(p+float64(j)*step, 1)
+			corr += a * b
+			energy += b * b
+		}
+		if energy > 0 {
+			corr /= math.Sqrt(energy)
+		}
+		if corr > bestCorr {
+			bestCorr = corr
+			best = float64(d)
+		}
+	}
+	return best
+}
+
+func (t *TimePitch) processGrain() error {
+	if !t.started {
+		// The first grain starts one hop before the source so that the source starts at the full gain.
+		t.grainPos = -float64(t.hop) * t.speed
+	}
+	step := t.pitch
+	pos := t.grainPos
+
+	// Read enough source for the search, the grain and the next hop.
+	end := int64(math.Ceil(math.Max(pos+float64(t.searchSize)+float64(t.grainSize)*step, pos+float64(t.hop)*t.speed))) + 1
+	if err := t.fill(end); err != nil {
+		return err
+	}
+
+	offset := t.bestOffset(pos, step)
+	for j := 0; j < t.grainSize; j++ {
+		w := t.window[j]
+		for ch := 0; ch < channelCount; ch++ {
+			t.accum[j*channelCount+ch] += t.at(pos+offset+float64(j)*step, ch) * w
+		}
+	}
+
+	// The first hop of the first grain is before the start of the source, and only fades in. Discard it.
+	n := t.hop
+	if !t.started {
+		n = 0
+	}
+	if t.inEOF {
+		// Stop at the output frame corresponding to the end of the source.
+		srcEnd := float64(t.inStart + int64(len(t.in)/channelCount))
+		if rest := int(math.Ceil((srcEnd - pos) / t.speed)); rest <= t.hop {
+			if rest < 0 {
+				rest = 0
+			}
+			if n > rest {
+				n = rest
+			}
+			t.done = true
+		}
+	}
+	t.emit(n)
+
+	t.prevPos = pos + offset
+	t.prevStep = step
+	t.started = true
+	t.grainPos += float64(t.hop) * t.speed
+
+	// Discard the source that is no longer needed.
+	keep := int64(math.Floor(math.Min(t.prevPos, t.grainPos-float64(t.searchSize)))) - 1
+	if keep > t.inStart {
+		d := int(keep-t.inStart) * channelCount
+		if d > len(t.in) {
+			d = len(t.in)
+		}
+		t.in = t.in[:copy(t.in, t.in[d:])]
+		t.inStart += int64(d / channelCount)
+	}
+	return nil
+}
+
+// emit moves the first n frames of the accumulation buffer to the output bytes, and shifts the buffer by a hop.
+func (t *TimePitch) emit(n int) {
+	t.ready = t.ready[:0]
+	t.readyPos = 0
+	for i := 0; i < n*channelCount; i++ {
+		v := t.accum[i]
+		if v > 1<<15-1 {
+			v = 1<<15 - 1
+		}
+		if v < -(1 << 15) {
+			v = -(1 << 15)
+		}
+		v16 := int16(v)
+		t.ready = append(t.ready, byte(v16), byte(v16>>8))
+	}
+	c := copy(t.accum, t.accum[t.hop*channelCount:])
+	for i := c; i < len(t.accum); i++ {
+		t.accum[i] = 0
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// zeroCrossingFrequency estimates the frequency of the left channel by counting rising zero crossings.
+func zeroCrossingFrequency(b []byte, sampleRate int) float64 {
+	var crossings int
+	var first, last int
+	prev := int16(b[0]) | int16(b[1])<<8
+	for i := 1; i < len(b)/4; i++ {
+		v := int16(b[4*i]) | int16(b[4*i+1])<<8
+		if prev < 0 && v >= 0 {
+			if crossings == 0 {
+				first = i
+			}
+			last = i
+			crossings++
+		}
+		prev = v
+	}
+	return float64(crossings-1) * float64(sampleRate) / float64(last-first)
+}
+
+func TestTimePitch(t *testing.T) {
+	const (
+		sampleRate = 44100
+		freq       = 440
+	)
+
+	cases := []struct {
+		Pitch     float64
+		Speed     float64
+		WantFreq  float64
+		WantRatio float64
+	}{
+		{
+			Pitch:     0,
+			Speed:     1,
+			WantFreq:  freq,
+			WantRatio: 1,
+		},
+		{
+			Pitch:     0,
+			Speed:     2,
+			WantFreq:  freq,
+			WantRatio: 0.5,
+		},
+		{
+			Pitch:     0,
+			Speed:     0.5,
+			WantFreq:  freq,
+			WantRatio: 2,
+		},
+		{
+			Pitch:     12,
+			Speed:     1,
+			WantFreq:  freq * 2,
+			WantRatio: 1,
+		},
+		{
+			Pitch:     -12,
+			Speed:     2,
+			WantFreq:  freq / 2,
+			WantRatio: 0.5,
+		},
+	}
+	for _, c := range cases {
+		src := newSineStream(freq)
+		src = append(src, newSineStream(freq)...)
+		s := audio.NewTimePitch(bytes.NewReader(src), sampleRate, nil)
+		s.SetPitch(c.Pitch)
+		s.SetSpeed(c.Speed)
+		got, err := io.ReadAll(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ratio := float64(len(got)) / float64(len(src)); math.Abs(ratio-c.WantRatio) > c.WantRatio*0.05 {
+			t.Errorf("pitch: %f, speed: %f: length ratio: got: %f, want: %f", c.Pitch, c.Speed, ratio, c.WantRatio)
+		}
+		if f := zeroCrossingFrequency(got, sampleRate); math.Abs(f-c.WantFreq) > c.WantFreq*0.02 {
+			t.Errorf("pitch: %f, speed: %f: frequency: got: %f, want: %f", c.Pitch, c.Speed, f, c.WantFreq)
+		}
+	}
+}
+
+func TestTimePitchIdentity(t *testing.T) {
+	src := newSineStream(440)
+	got, err := io.ReadAll(audio.NewTimePitch(bytes.NewReader(src), 44100, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(src) {
+		t.Fatalf("len(got): %d, want: %d", len(got), len(src))
+	}
+	for i := 0; i < len(got)/2; i++ {
+		g := int16(got[2*i]) | int16(got[2*i+1])<<8
+		w := int16(src[2*i]) | int16(src[2*i+1])<<8
+		if d := int(g) - int(w); d < -2 || d > 2 {
+			t.Errorf("sample %d: got: %d, want: %d", i, g, w)
+			break
+		}
+	}
+}
+
+func TestTimePitchLowSampleRate(t *testing.T) {
+	const sampleRate = 10
+
+	src := make([]byte, 4*sampleRate)
+	for _, pitch := range []float64{-12, 0, 12} {
+		tp := audio.NewTimePitch(bytes.NewReader(src), sampleRate, &audio.TimePitchOptions{
+			GrainSize: 10 * time.Millisecond,
+		})
+		tp.SetPitch(pitch)
+		got, err := io.ReadAll(tp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(src) {
+			t.Errorf("pitch: %f, len(got): %d, want: %d", pitch, len(got), len(src))
+		}
+	}
+}
+
+func TestTimePitchInvalidSampleRate(t *testing.T) {
+	for _, sampleRate := range []int{0, -44100} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewTimePitch with sample rate %d must panic", sampleRate)
+				}
+			}()
+			audio.NewTimePitch(bytes.NewReader(nil), sampleRate, nil)
+		}()
+	}
+}
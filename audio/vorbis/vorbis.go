@@ -162,7 +162,7 @@ func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
 	var s io.ReadSeeker = decoded
 	size := decoded.Length()
 	if channelCount == 1 {
-		s = convert.NewStereo16(s, true, false)
+		s = convert.NewStereo16(s, true, 2)
 		size *= 2
 	}
 	stream := &Stream{
@@ -196,7 +196,7 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 	var s io.ReadSeeker = decoded
 	size := decoded.Length()
 	if channelCount == 1 {
-		s = convert.NewStereo16(s, true, false)
+		s = convert.NewStereo16(s, true, 2)
 		size *= 2
 	}
 	if origSampleRate != sampleRate {
@@ -223,3 +223,15 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 func Decode(context *audio.Context, src io.Reader) (*Stream, error) {
 	return DecodeWithSampleRate(context.SampleRate(), src)
 }
+
+func init() {
+	audio.RegisterDecoder("ogg", "OggS", decodeAny)
+}
+
+func decodeAny(sampleRate int, src io.Reader) (io.ReadSeeker, error) {
+	s, err := DecodeWithSampleRate(sampleRate, src)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
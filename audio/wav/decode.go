@@ -104,7 +104,8 @@ func (s *stream) Seek(offset int64, whence int) (int64, error) {
 
 // DecodeWithoutResampling decodes WAV (RIFF) data to playable stream.
 //
-// The format must be 1 or 2 channels, 8bit or 16bit little endian PCM.
+// The format must be 1 or 2 channels, 8bit, 16bit, 24bit or 32bit little endian PCM.
+// WAVE_FORMAT_EXTENSIBLE with the PCM sub-format is also accepted.
 // The format is converted into 2 channels and 16bit.
 //
 // DecodeWithSampleRate returns error when decoding fails or IO error happens.
@@ -123,7 +124,8 @@ func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
 
 // DecodeWithSampleRate decodes WAV (RIFF) data to playable stream.
 //
-// The format must be 1 or 2 channels, 8bit or 16bit little endian PCM.
+// The format must be 1 or 2 channels, 8bit, 16bit, 24bit or 32bit little endian PCM.
+// WAVE_FORMAT_EXTENSIBLE with the PCM sub-format is also accepted.
 // The format is converted into 2 channels and 16bit.
 //
 // DecodeWithSampleRate returns error when decoding fails or IO error happens.
@@ -154,6 +156,15 @@ func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
 	}, nil
 }
 
+const (
+	formatPCM        = 0x0001
+	formatExtensible = 0xfffe
+)
+
+// pcmSubFormatGUIDSuffix is the common suffix of the sub-format GUIDs in WAVE_FORMAT_EXTENSIBLE.
+// The first 2 bytes of a GUID are the format code, e.g. 0x0001 for PCM (KSDATAFORMAT_SUBTYPE_PCM).
+var pcmSubFormatGUIDSuffix = []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0xaa, 0x00, 0x38, 0x9b, 0x71}
+
 func decode(src io.Reader) (*Stream, int, error) {
 	buf := make([]byte, 12)
 	n, err := io.ReadFull(src, buf)
@@ -203,7 +214,17 @@ chunks:
 				return nil, 0, err
 			}
 			format := int(buf[0]) | int(buf[1])<<8
-			if format != 1 {
+			if format == formatExtensible {
+				// The actual format is specified by the sub-format GUID in the extension.
+				if size < 40 {
+					return nil, 0, fmt.Errorf("wav: invalid header: the extension of WAVE_FORMAT_EXTENSIBLE is too short")
+				}
+				if !bytes.Equal(buf[26:40], pcmSubFormatGUIDSuffix) {
+					return nil, 0, fmt.Errorf("wav: format must be linear PCM")
+				}
+				format = int(buf[24]) | int(buf[25])<<8
+			}
+			if format != formatPCM {
 				return nil, 0, fmt.Errorf("wav: format must be linear PCM")
 			}
 			channelCount := int(buf[2]) | int(buf[3])<<8
@@ -216,8 +237,8 @@ chunks:
 				return nil, 0, fmt.Errorf("wav: number of channels must be 1 or 2 but was %d", channelCount)
 			}
 			bitsPerSample = int(buf[14]) | int(buf[15])<<8
-			if bitsPerSample != 8 && bitsPerSample != 16 {
-				return nil, 0, fmt.Errorf("wav: bits per sample must be 8 or 16 but was %d", bitsPerSample)
+			if bitsPerSample != 8 && bitsPerSample != 16 && bitsPerSample != 24 && bitsPerSample != 32 {
+				return nil, 0, fmt.Errorf("wav: bits per sample must be 8, 16, 24 or 32 but was %d", bitsPerSample)
 			}
 			sampleRate = int(buf[4]) | int(buf[5])<<8 | int(buf[6])<<16 | int(buf[7])<<24
 			headerSize += size
@@ -244,20 +265,19 @@ chunks:
 	}
 
 	if mono || bitsPerSample != 16 {
-		s = convert.NewStereo16(s, mono, bitsPerSample != 16)
+		s = convert.NewStereo16(s, mono, bitsPerSample/8)
 		if mono {
 			dataSize *= 2
 		}
-		if bitsPerSample != 16 {
-			dataSize *= 2
-		}
+		dataSize = dataSize * 16 / int64(bitsPerSample)
 	}
 	return &Stream{inner: s, size: dataSize}, sampleRate, nil
 }
 
 // Decode decodes WAV (RIFF) data to playable stream.
 //
-// The format must be 1 or 2 channels, 8bit or 16bit little endian PCM.
+// The format must be 1 or 2 channels, 8bit, 16bit, 24bit or 32bit little endian PCM.
+// WAVE_FORMAT_EXTENSIBLE with the PCM sub-format is also accepted.
 // The format is converted into 2 channels and 16bit.
 //
 // Decode returns error when decoding fails or IO error happens.
@@ -273,3 +293,15 @@ chunks:
 func Decode(context *audio.Context, src io.Reader) (*Stream, error) {
 	return DecodeWithSampleRate(context.SampleRate(), src)
 }
+
+func init() {
+	audio.RegisterDecoder("wav", "RIFF????WAVE", decodeAny)
+}
+
+func decodeAny(sampleRate int, src io.Reader) (io.ReadSeeker, error) {
+	s, err := DecodeWithSampleRate(sampleRate, src)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
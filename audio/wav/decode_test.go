@@ -0,0 +1,183 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wav_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+const testSampleRate = 44100
+
+// fmtChunk returns the content of a 'fmt ' chunk.
+func fmtChunk(format uint16, channelCount int, bitsPerSample int) []byte {
+	blockAlign := channelCount * bitsPerSample / 8
+	var b []byte
+	b = binary.LittleEndian.AppendUint16(b, format)
+	b = binary.LittleEndian.AppendUint16(b, uint16(channelCount))
+	b = binary.LittleEndian.AppendUint32(b, testSampleRate)
+	b = binary.LittleEndian.AppendUint32(b, uint32(testSampleRate*blockAlign))
+	b = binary.LittleEndian.AppendUint16(b, uint16(blockAlign))
+	b = binary.LittleEndian.AppendUint16(b, uint16(bitsPerSample))
+	return b
+}
+
+// extensibleFmtChunk returns the content of a 'fmt ' chunk of WAVE_FORMAT_EXTENSIBLE with the given sub-format code.
+func extensibleFmtChunk(subFormat uint16, channelCount int, bitsPerSample int) []byte {
+	b := fmtChunk(0xfffe, channelCount, bitsPerSample)
+	// The size of the extension.
+	b = binary.LittleEndian.AppendUint16(b, 22)
+	// The valid bits per sample.
+	b = binary.LittleEndian.AppendUint16(b, uint16(bitsPerSample))
+	// The channel mask.
+	b = binary.LittleEndian.AppendUint32(b, 0)
+	// The sub-format GUID.
+	b = binary.LittleEndian.AppendUint16(b, subFormat)
+	b = append(b, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0xaa, 0x00, 0x38, 0x9b, 0x71)
+	return b
+}
+
+// riff returns a WAV file with the given 'fmt ' chunk content and data.
+func riff(fmtChunk []byte, data []byte) []byte {
+	var b []byte
+	b = append(b, "RIFF"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(4+8+len(fmtChunk)+8+len(data)))
+	b = append(b, "WAVE"...)
+	b = append(b, "fmt "...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(fmtChunk)))
+	b = append(b, fmtChunk...)
+	b = append(b, "data"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(data)))
+	b = append(b, data...)
+	return b
+}
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Fmt     []byte
+		Data    []byte
+		Want    []byte
+		WantErr bool
+	}{
+		{
+			Name: "16bit stereo",
+			Fmt:  fmtChunk(1, 2, 16),
+			Data: []byte{0x34, 0x12, 0xcd, 0xab, 0x78, 0x56, 0x21, 0x43},
+			Want: []byte{0x34, 0x12, 0xcd, 0xab, 0x78, 0x56, 0x21, 0x43},
+		},
+		{
+			Name: "16bit mono",
+			Fmt:  fmtChunk(1, 1, 16),
+			Data: []byte{0x34, 0x12, 0xcd, 0xab},
+			Want: []byte{0x34, 0x12, 0x34, 0x12, 0xcd, 0xab, 0xcd, 0xab},
+		},
+		{
+			Name: "8bit mono",
+			Fmt:  fmtChunk(1, 1, 8),
+			Data: []byte{0x80, 0xff},
+			Want: []byte{0x80, 0x00, 0x80, 0x00, 0xff, 0x7f, 0xff, 0x7f},
+		},
+		{
+			Name: "24bit stereo",
+			Fmt:  fmtChunk(1, 2, 24),
+			Data: []byte{0xff, 0x34, 0x12, 0x01, 0xcd, 0xab},
+			Want: []byte{0x34, 0x12, 0xcd, 0xab},
+		},
+		{
+			Name: "24bit mono",
+			Fmt:  fmtChunk(1, 1, 24),
+			Data: []byte{0xff, 0x34, 0x12},
+			Want: []byte{0x34, 0x12, 0x34, 0x12},
+		},
+		{
+			Name: "32bit stereo",
+			Fmt:  fmtChunk(1, 2, 32),
+			Data: []byte{0xff, 0xff, 0x34, 0x12, 0x01, 0x02, 0xcd, 0xab},
+			Want: []byte{0x34, 0x12, 0xcd, 0xab},
+		},
+		{
+			Name: "extensible 16bit stereo",
+			Fmt:  extensibleFmtChunk(1, 2, 16),
+			Data: []byte{0x34, 0x12, 0xcd, 0xab},
+			Want: []byte{0x34, 0x12, 0xcd, 0xab},
+		},
+		{
+			Name: "extensible 24bit stereo",
+			Fmt:  extensibleFmtChunk(1, 2, 24),
+			Data: []byte{0xff, 0x34, 0x12, 0x01, 0xcd, 0xab},
+			Want: []byte{0x34, 0x12, 0xcd, 0xab},
+		},
+		{
+			Name:    "extensible IEEE float",
+			Fmt:     extensibleFmtChunk(3, 2, 32),
+			Data:    make([]byte, 8),
+			WantErr: true,
+		},
+		{
+			Name:    "extensible too short",
+			Fmt:     extensibleFmtChunk(1, 2, 16)[:24],
+			Data:    make([]byte, 4),
+			WantErr: true,
+		},
+		{
+			Name:    "IEEE float",
+			Fmt:     fmtChunk(3, 2, 32),
+			Data:    make([]byte, 8),
+			WantErr: true,
+		},
+		{
+			Name:    "12bit",
+			Fmt:     fmtChunk(1, 2, 12),
+			Data:    make([]byte, 6),
+			WantErr: true,
+		},
+		{
+			Name:    "3 channels",
+			Fmt:     fmtChunk(1, 3, 16),
+			Data:    make([]byte, 6),
+			WantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			s, err := wav.DecodeWithoutResampling(bytes.NewReader(riff(c.Fmt, c.Data)))
+			if c.WantErr {
+				if err == nil {
+					t.Errorf("DecodeWithoutResampling must return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := s.Length(), int64(len(c.Want)); got != want {
+				t.Errorf("Length(): got: %d, want: %d", got, want)
+			}
+			got, err := io.ReadAll(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, c.Want) {
+				t.Errorf("got: %v, want: %v", got, c.Want)
+			}
+		})
+	}
+}
@@ -85,6 +85,32 @@ func (b Blend) internalBlend() graphicsdriver.Blend {
 	}
 }
 
+// Validate reports an error if b has an invalid blend factor or blend operation.
+//
+// Drawing functions panic with an invalid Blend.
+// Validate is useful to check a Blend built from external data like a configuration file before drawing.
+func (b Blend) Validate() error {
+	for _, f := range []BlendFactor{
+		b.BlendFactorSourceRGB,
+		b.BlendFactorSourceAlpha,
+		b.BlendFactorDestinationRGB,
+		b.BlendFactorDestinationAlpha,
+	} {
+		if f > BlendFactorOneMinusDestinationAlpha {
+			return fmt.Errorf("ebiten: invalid blend factor: %d", f)
+		}
+	}
+	for _, o := range []BlendOperation{
+		b.BlendOperationRGB,
+		b.BlendOperationAlpha,
+	} {
+		if o > BlendOperationMax {
+			return fmt.Errorf("ebiten: invalid blend operation: %d", o)
+		}
+	}
+	return nil
+}
+
 // BlendFactor is a factor for source and destination color values.
 type BlendFactor byte
 
@@ -407,4 +433,59 @@ var (
 		BlendOperationRGB:           BlendOperationAdd,
 		BlendOperationAlpha:         BlendOperationAdd,
 	}
+
+	// BlendMultiply is a preset Blend for 'multiply' separable blend mode.
+	// This is the same as 'multiply' in CSS compositing when the destination is opaque.
+	//
+	//     c_out = c_src × c_dst + c_dst × (1 - α_src)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendMultiply = Blend{
+		BlendFactorSourceRGB:        BlendFactorDestinationColor,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOneMinusSourceAlpha,
+		BlendFactorDestinationAlpha: BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           BlendOperationAdd,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
+
+	// BlendScreen is a preset Blend for 'screen' separable blend mode.
+	//
+	//     c_out = c_src + c_dst × (1 - c_src)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendScreen = Blend{
+		BlendFactorSourceRGB:        BlendFactorOne,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOneMinusSourceColor,
+		BlendFactorDestinationAlpha: BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           BlendOperationAdd,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
+
+	// BlendDarken is a preset Blend for 'darken' separable blend mode.
+	// This is the same as 'darken' in CSS compositing when both the source and the destination are opaque.
+	//
+	//     c_out = min(c_src, c_dst)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendDarken = Blend{
+		BlendFactorSourceRGB:        BlendFactorOne,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOne,
+		BlendFactorDestinationAlpha: BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           BlendOperationMin,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
+
+	// BlendLighten is a preset Blend for 'lighten' separable blend mode.
+	// This is the same as 'lighten' in CSS compositing when both the source and the destination are opaque.
+	//
+	//     c_out = max(c_src, c_dst)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendLighten = Blend{
+		BlendFactorSourceRGB:        BlendFactorOne,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOne,
+		BlendFactorDestinationAlpha: BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           BlendOperationMax,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
 )
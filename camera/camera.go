@@ -0,0 +1,171 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package camera provides a 2D camera that maps the world coordinates to the screen coordinates.
+package camera
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Camera represents a 2D camera with a position, a zoom and a rotation.
+//
+// The camera's position is the point in the world that is shown at the center of the viewport.
+type Camera struct {
+	x        float64
+	y        float64
+	zoom     float64
+	rotation float64
+
+	viewportWidth  float64
+	viewportHeight float64
+}
+
+// NewCamera creates a new Camera with the given viewport size, which is usually the screen size.
+//
+// The initial position is (0, 0), the initial zoom is 1 and the initial rotation is 0.
+func NewCamera(viewportWidth, viewportHeight float64) *Camera {
+	return &Camera{
+		zoom:           1,
+		viewportWidth:  viewportWidth,
+		viewportHeight: viewportHeight,
+	}
+}
+
+// SetViewportSize sets the viewport size, e.g. when the screen size is changed.
+func (c *Camera) SetViewportSize(width, height float64) {
+	c.viewportWidth = width
+	c.viewportHeight = height
+}
+
+// Position returns the camera's position in the world.
+func (c *Camera) Position() (x, y float64) {
+	return c.x, c.y
+}
+
+// SetPosition sets the camera's position in the world.
+func (c *Camera) SetPosition(x, y float64) {
+	c.x = x
+	c.y = y
+}
+
+// Move moves the camera's position by (dx, dy) in the world.
+func (c *Camera) Move(dx, dy float64) {
+	c.x += dx
+	c.y += dy
+}
+
+// Zoom returns the camera's zoom factor.
+func (c *Camera) Zoom() float64 {
+	return c.zoom
+}
+
+// SetZoom sets the camera's zoom factor around the center of the viewport.
+// 2 shows the world twice as large.
+//
+// zoom must be positive. SetZoom panics otherwise.
+func (c *Camera) SetZoom(zoom float64) {
+	if zoom <= 0 {
+		panic(fmt.Sprintf("camera: zoom must be positive but %f", zoom))
+	}
+	c.zoom = zoom
+}
+
+// ZoomAt multiplies the camera's zoom factor by factor, keeping the world point at the screen position (screenX, screenY).
+// This is useful to zoom at the mouse cursor.
+//
+// factor must be positive. ZoomAt panics otherwise.
+func (c *Camera) ZoomAt(screenX, screenY float64, factor float64) {
+	if factor <= 0 {
+		panic(fmt.Sprintf("camera: factor must be positive but %f", factor))
+	}
+	wx, wy := c.ScreenToWorld(screenX, screenY)
+	c.zoom *= factor
+	nx, ny := c.ScreenToWorld(screenX, screenY)
+	c.x += wx - nx
+	c.y += wy - ny
+}
+
+// Rotation returns the camera's rotation in radians.
+func (c *Camera) Rotation() float64 {
+	return c.rotation
+}
+
+// SetRotation sets the camera's rotation in radians around the center of the viewport.
+// A positive rotation rotates the camera clockwise, so the world appears rotated counterclockwise.
+func (c *Camera) SetRotation(theta float64) {
+	c.rotation = theta
+}
+
+// Rotate adds theta in radians to the camera's rotation.
+func (c *Camera) Rotate(theta float64) {
+	c.rotation += theta
+}
+
+// Matrix returns the geometry matrix to transform the world coordinates to the screen coordinates.
+//
+// Concat this matrix to the GeoM of DrawImageOptions after placing an image in the world:
+//
+//	op := &ebiten.DrawImageOptions{}
+//	op.GeoM.Translate(objectX, objectY)
+//	op.GeoM.Concat(camera.Matrix())
+//	screen.DrawImage(img, op)
+func (c *Camera) Matrix() ebiten.GeoM {
+	var g ebiten.GeoM
+	g.Translate(-c.x, -c.y)
+	g.Rotate(-c.rotation)
+	g.Scale(c.zoom, c.zoom)
+	g.Translate(c.viewportWidth/2, c.viewportHeight/2)
+	return g
+}
+
+// WorldToScreen converts the world coordinates to the screen coordinates.
+func (c *Camera) WorldToScreen(worldX, worldY float64) (screenX, screenY float64) {
+	g := c.Matrix()
+	return g.Apply(worldX, worldY)
+}
+
+// ScreenToWorld converts the screen coordinates to the world coordinates.
+// This is useful to find the world point under the mouse cursor.
+func (c *Camera) ScreenToWorld(screenX, screenY float64) (worldX, worldY float64) {
+	g := c.Matrix()
+	g.Invert()
+	return g.Apply(screenX, screenY)
+}
+
+// WorldBounds returns the smallest rectangle in the world that covers the whole viewport.
+//
+// When the camera is rotated, the rectangle is the bounding box of the rotated viewport.
+// This is useful to cull objects out of the screen.
+func (c *Camera) WorldBounds() image.Rectangle {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range [][2]float64{
+		{0, 0},
+		{c.viewportWidth, 0},
+		{0, c.viewportHeight},
+		{c.viewportWidth, c.viewportHeight},
+	} {
+		x, y := c.ScreenToWorld(p[0], p[1])
+		minX = math.Min(minX, x)
+		minY = math.Min(minY, y)
+		maxX = math.Max(maxX, x)
+		maxY = math.Max(maxY, y)
+	}
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package camera_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/camera"
+)
+
+const epsilon = 1e-9
+
+func TestRoundTrip(t *testing.T) {
+	c := camera.NewCamera(640, 480)
+	c.SetPosition(123.5, -45.25)
+	c.SetZoom(2.5)
+	c.SetRotation(0.7)
+
+	for _, p := range [][2]float64{{0, 0}, {100, 200}, {-300.5, 42.25}, {1e4, -1e4}} {
+		sx, sy := c.WorldToScreen(p[0], p[1])
+		wx, wy := c.ScreenToWorld(sx, sy)
+		if math.Abs(wx-p[0]) > epsilon*math.Abs(p[0])+epsilon || math.Abs(wy-p[1]) > epsilon*math.Abs(p[1])+epsilon {
+			t.Errorf("ScreenToWorld(WorldToScreen(%f, %f)): got: (%f, %f)", p[0], p[1], wx, wy)
+		}
+
+		g := c.Matrix()
+		mx, my := g.Apply(p[0], p[1])
+		if mx != sx || my != sy {
+			t.Errorf("Matrix().Apply(%f, %f): got: (%f, %f), want: (%f, %f)", p[0], p[1], mx, my, sx, sy)
+		}
+	}
+}
+
+func TestCenter(t *testing.T) {
+	c := camera.NewCamera(640, 480)
+	c.SetPosition(10, 20)
+	c.SetZoom(3)
+	c.SetRotation(1.2)
+
+	// The camera's position is at the center of the viewport regardless of the zoom and the rotation.
+	sx, sy := c.WorldToScreen(10, 20)
+	if math.Abs(sx-320) > epsilon || math.Abs(sy-240) > epsilon {
+		t.Errorf("WorldToScreen(10, 20): got: (%f, %f), want: (320, 240)", sx, sy)
+	}
+
+	// Without rotation, a zoom scales the distance from the center.
+	c.SetRotation(0)
+	sx, sy = c.WorldToScreen(11, 20)
+	if math.Abs(sx-323) > epsilon || math.Abs(sy-240) > epsilon {
+		t.Errorf("WorldToScreen(11, 20): got: (%f, %f), want: (323, 240)", sx, sy)
+	}
+}
+
+func TestZoomAt(t *testing.T) {
+	c := camera.NewCamera(640, 480)
+	c.SetPosition(50, 60)
+	c.SetRotation(0.3)
+
+	wx, wy := c.ScreenToWorld(100, 400)
+	c.ZoomAt(100, 400, 1.5)
+	c.ZoomAt(100, 400, 1.5)
+	if got, want := c.Zoom(), 2.25; math.Abs(got-want) > epsilon {
+		t.Errorf("Zoom(): got: %f, want: %f", got, want)
+	}
+	sx, sy := c.WorldToScreen(wx, wy)
+	if math.Abs(sx-100) > 1e-6 || math.Abs(sy-400) > 1e-6 {
+		t.Errorf("WorldToScreen after ZoomAt: got: (%f, %f), want: (100, 400)", sx, sy)
+	}
+}
+
+func TestWorldBounds(t *testing.T) {
+	c := camera.NewCamera(640, 480)
+	c.SetPosition(320, 240)
+	c.SetZoom(2)
+	if got, want := c.WorldBounds(), image.Rect(160, 120, 480, 360); got != want {
+		t.Errorf("WorldBounds(): got: %v, want: %v", got, want)
+	}
+}
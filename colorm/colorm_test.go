@@ -145,6 +145,23 @@ func TestColorMMonochrome(t *testing.T) {
 	}
 }
 
+func TestColorMRotateHueFullTurn(t *testing.T) {
+	m := colorm.ColorM{}
+	m.RotateHue(2 * math.Pi)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 5; j++ {
+			got := m.Element(i, j)
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(want-got) > 0.0001 {
+				t.Errorf("m.Element(%d, %d) = %f, want %f", i, j, got, want)
+			}
+		}
+	}
+}
+
 func TestColorMConcatSelf(t *testing.T) {
 	expected := [4][5]float64{
 		{30, 40, 30, 25, 30},
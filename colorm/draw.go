@@ -48,10 +48,22 @@ func DrawImage(dst, src *ebiten.Image, colorM ColorM, op *DrawImageOptions) {
 	opShader.Blend = op.Blend
 	opShader.Uniforms = uniforms(colorM)
 	opShader.Images[0] = src
-	s := builtinShader(builtinshader.Filter(op.Filter), builtinshader.AddressUnsafe)
+	filter := builtinshader.Filter(op.Filter)
+	// The bicubic filter doesn't improve minification. Use the linear filter as ebiten.DrawImage does.
+	if filter == builtinshader.FilterBicubic && isMinification(op.GeoM) {
+		filter = builtinshader.FilterLinear
+	}
+	s := builtinShader(filter, builtinshader.AddressUnsafe)
 	dst.DrawRectShader(src.Bounds().Dx(), src.Bounds().Dy(), s, opShader)
 }
 
+// isMinification reports whether geom shrinks an image along any of the axes.
+func isMinification(geom ebiten.GeoM) bool {
+	a, b, c, d := geom.Element(0, 0), geom.Element(0, 1), geom.Element(1, 0), geom.Element(1, 1)
+	// Check the lengths of the images of the unit vectors.
+	return a*a+c*c < 1 || b*b+d*d < 1
+}
+
 // DrawTrianglesOptions represents options for DrawTriangles.
 type DrawTrianglesOptions struct {
 	// ColorScaleMode is the mode of color scales in vertices.
@@ -275,6 +275,46 @@ func TestColorMAndScale(t *testing.T) {
 	}
 }
 
+func TestDrawImageBicubicMinification(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			if (i+j)%3 == 0 {
+				pix[idx] = 0xff
+				pix[idx+1] = 0xff
+				pix[idx+2] = 0xff
+			}
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix)
+
+	var cm colorm.ColorM
+	cm.Scale(1, 0.5, 0.5, 1)
+
+	// FilterBicubic for minification must be the same as FilterLinear.
+	draw := func(filter ebiten.Filter) *ebiten.Image {
+		dst := ebiten.NewImage(w, h)
+		op := &colorm.DrawImageOptions{}
+		op.GeoM.Scale(2, 0.5)
+		op.Filter = filter
+		colorm.DrawImage(dst, src, cm, op)
+		return dst
+	}
+	got := draw(ebiten.FilterBicubic)
+	want := draw(ebiten.FilterLinear)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if got, want := got.At(i, j).(color.RGBA), want.At(i, j).(color.RGBA); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
 // Issue #1213
 func TestColorMCopy(t *testing.T) {
 	const w, h = 16, 16
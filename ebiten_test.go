@@ -15,6 +15,7 @@
 package ebiten_test
 
 import (
+	"image"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -30,3 +31,12 @@ func TestScreenSizeInFullscreen(t *testing.T) {
 		t.Errorf("h must be positive but not: %d", h)
 	}
 }
+
+func TestSetCustomCursorNilFrame(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetCustomCursor with a nil frame must panic")
+		}
+	}()
+	ebiten.SetCustomCursor([]*ebiten.Image{ebiten.NewImage(16, 16), nil}, image.Point{}, 10)
+}
@@ -20,9 +20,11 @@ import (
 	"bytes"
 	_ "embed"
 	"image"
+	"image/color"
 	_ "image/png"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 //go:embed text.png
@@ -52,18 +54,98 @@ func DebugPrint(image *ebiten.Image, str string) {
 //
 // The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement.
 func DebugPrintAt(image *ebiten.Image, str string, x, y int) {
-	drawDebugText(image, str, x, y)
+	var geoM ebiten.GeoM
+	geoM.Translate(float64(x), float64(y))
+	drawDebugText(image, str, geoM, ebiten.ColorScale{})
 }
 
-func drawDebugText(rt *ebiten.Image, str string, ox, oy int) {
+// DebugPrintOptions represents options for DebugPrintWithOptions.
+type DebugPrintOptions struct {
+	// X and Y are the position of the left top corner of the text.
+	X float64
+	Y float64
+
+	// Scale is the scale of the text.
+	//
+	// The default (zero) value means 1.
+	Scale float64
+
+	// Color is the color of the text.
+	//
+	// The default (nil) value means white.
+	Color color.Color
+
+	// BackgroundColor is the color of the rectangle drawn behind the text.
+	// A semi-transparent color like color.RGBA{0, 0, 0, 0x80} makes the text legible on colorful scenes.
+	//
+	// The default (nil) value means that no background is drawn.
+	BackgroundColor color.Color
+}
+
+// DebugPrintWithOptions draws the string str on the image with the options.
+//
+// If options is nil, DebugPrintWithOptions works in the same way as DebugPrint.
+//
+// The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement.
+func DebugPrintWithOptions(image *ebiten.Image, str string, options *DebugPrintOptions) {
+	if options == nil {
+		options = &DebugPrintOptions{}
+	}
+
+	scale := options.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	if options.BackgroundColor != nil {
+		w, h := debugTextSize(str)
+		vector.DrawFilledRect(image, float32(options.X), float32(options.Y), float32(float64(w)*scale), float32(float64(h)*scale), options.BackgroundColor, false)
+	}
+
+	var geoM ebiten.GeoM
+	geoM.Scale(scale, scale)
+	geoM.Translate(options.X, options.Y)
+	var colorScale ebiten.ColorScale
+	if options.Color != nil {
+		colorScale.ScaleWithColor(options.Color)
+	}
+	drawDebugText(image, str, geoM, colorScale)
+}
+
+const (
+	debugPrintCharWidth  = 6
+	debugPrintCharHeight = 16
+)
+
+// debugTextSize returns the size of the text in pixels without scaling.
+func debugTextSize(str string) (int, int) {
+	var w, x int
+	h := debugPrintCharHeight
+	for _, c := range str {
+		if c == '\n' {
+			x = 0
+			h += debugPrintCharHeight
+			continue
+		}
+		x += debugPrintCharWidth
+		if w < x {
+			w = x
+		}
+	}
+	// The glyphs are drawn 1 pixel right from the origin.
+	return w + 1, h
+}
+
+func drawDebugText(rt *ebiten.Image, str string, geoM ebiten.GeoM, colorScale ebiten.ColorScale) {
 	op := &ebiten.DrawImageOptions{}
+	op.ColorScale = colorScale
 	x := 0
 	y := 0
 	w := debugPrintTextImage.Bounds().Dx()
 	for _, c := range str {
 		const (
-			cw = 6
-			ch = 16
+			cw = debugPrintCharWidth
+			ch = debugPrintCharHeight
 		)
 		if c == '\n' {
 			x = 0
@@ -80,7 +162,8 @@ func drawDebugText(rt *ebiten.Image, str string, ox, oy int) {
 		}
 		op.GeoM.Reset()
 		op.GeoM.Translate(float64(x), float64(y))
-		op.GeoM.Translate(float64(ox+1), float64(oy))
+		op.GeoM.Translate(1, 0)
+		op.GeoM.Concat(geoM)
 		rt.DrawImage(s, op)
 		x += cw
 	}
@@ -0,0 +1,148 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const debugPrintTestText = "Hello,\nWorld!"
+
+// drawnBounds returns the bounds of the non-transparent pixels of img.
+func drawnBounds(img *ebiten.Image) image.Rectangle {
+	var r image.Rectangle
+	b := img.Bounds()
+	for j := b.Min.Y; j < b.Max.Y; j++ {
+		for i := b.Min.X; i < b.Max.X; i++ {
+			if _, _, _, a := img.At(i, j).RGBA(); a == 0 {
+				continue
+			}
+			r = r.Union(image.Rect(i, j, i+1, j+1))
+		}
+	}
+	return r
+}
+
+func TestDebugPrintWithOptionsNil(t *testing.T) {
+	want := ebiten.NewImage(64, 48)
+	ebitenutil.DebugPrint(want, debugPrintTestText)
+	got := ebiten.NewImage(64, 48)
+	ebitenutil.DebugPrintWithOptions(got, debugPrintTestText, nil)
+
+	for j := 0; j < 48; j++ {
+		for i := 0; i < 64; i++ {
+			if got, want := got.At(i, j), want.At(i, j); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDebugPrintWithOptionsPosition(t *testing.T) {
+	want := ebiten.NewImage(64, 64)
+	ebitenutil.DebugPrintAt(want, debugPrintTestText, 10, 20)
+	got := ebiten.NewImage(64, 64)
+	ebitenutil.DebugPrintWithOptions(got, debugPrintTestText, &ebitenutil.DebugPrintOptions{
+		X: 10,
+		Y: 20,
+	})
+
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 64; i++ {
+			if got, want := got.At(i, j), want.At(i, j); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDebugPrintWithOptionsScale(t *testing.T) {
+	base := ebiten.NewImage(128, 96)
+	ebitenutil.DebugPrintAt(base, debugPrintTestText, 0, 0)
+	scaled := ebiten.NewImage(128, 96)
+	ebitenutil.DebugPrintWithOptions(scaled, debugPrintTestText, &ebitenutil.DebugPrintOptions{
+		Scale: 2,
+	})
+
+	b := drawnBounds(base)
+	if b.Empty() {
+		t.Fatalf("DebugPrintAt must draw the text")
+	}
+	got := drawnBounds(scaled)
+	want := image.Rect(2*b.Min.X, 2*b.Min.Y, 2*b.Max.X, 2*b.Max.Y)
+	if got != want {
+		t.Errorf("drawn bounds: got: %v, want: %v", got, want)
+	}
+}
+
+func TestDebugPrintWithOptionsColor(t *testing.T) {
+	base := ebiten.NewImage(64, 48)
+	ebitenutil.DebugPrintAt(base, debugPrintTestText, 0, 0)
+	colored := ebiten.NewImage(64, 48)
+	ebitenutil.DebugPrintWithOptions(colored, debugPrintTestText, &ebitenutil.DebugPrintOptions{
+		Color: color.RGBA{R: 0xff, A: 0xff},
+	})
+
+	for j := 0; j < 48; j++ {
+		for i := 0; i < 64; i++ {
+			// The green and the blue components are removed.
+			b := base.At(i, j).(color.RGBA)
+			want := color.RGBA{R: b.R, A: b.A}
+			if got := colored.At(i, j).(color.RGBA); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDebugPrintWithOptionsBackgroundColor(t *testing.T) {
+	const x, y = 4, 8
+
+	base := ebiten.NewImage(64, 64)
+	ebitenutil.DebugPrintAt(base, debugPrintTestText, x, y)
+	bg := color.RGBA{B: 0xff, A: 0xff}
+	got := ebiten.NewImage(64, 64)
+	ebitenutil.DebugPrintWithOptions(got, debugPrintTestText, &ebitenutil.DebugPrintOptions{
+		X:               x,
+		Y:               y,
+		BackgroundColor: bg,
+	})
+
+	// The background covers the text.
+	b := drawnBounds(got)
+	if !drawnBounds(base).In(b) {
+		t.Errorf("the background %v must cover the text %v", b, drawnBounds(base))
+	}
+	if got, want := b.Min, image.Pt(x, y); got != want {
+		t.Errorf("the background position: got: %v, want: %v", got, want)
+	}
+
+	// The pixels without the text are the background color.
+	for j := b.Min.Y; j < b.Max.Y; j++ {
+		for i := b.Min.X; i < b.Max.X; i++ {
+			if base.At(i, j).(color.RGBA).A != 0 {
+				continue
+			}
+			if got := got.At(i, j).(color.RGBA); got != bg {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, bg)
+			}
+		}
+	}
+}
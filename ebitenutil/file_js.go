@@ -15,17 +15,25 @@
 package ebitenutil
 
 import (
-	"bytes"
 	"io"
+	"io/fs"
 	"net/http"
 )
 
-type file struct {
-	*bytes.Reader
-}
+// httpFS is a file system whose files are fetched with HTTP GET requests.
+type httpFS struct{}
 
-func (f *file) Close() error {
-	return nil
+func (httpFS) Open(name string) (fs.File, error) {
+	res, err := http.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return newMemFile(name, body), nil
 }
 
 // OpenFile opens a file and returns a stream for its data.
@@ -36,15 +44,9 @@ func (f *file) Close() error {
 //
 // Deprecated: as of v2.4. Use os.Open on desktops and http.Get on browsers instead.
 func OpenFile(path string) (ReadSeekCloser, error) {
-	res, err := http.Get(path)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	f := &file{bytes.NewReader(body)}
-	return f, nil
+	return OpenFileSystem(httpFS{}, path)
+}
+
+func defaultFileSystem() fs.FS {
+	return httpFS{}
 }
@@ -17,10 +17,19 @@
 package ebitenutil
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 )
 
+// osFS is a file system of the OS.
+// As opposed to os.DirFS, osFS accepts absolute paths and paths relative to the current directory.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(filepath.FromSlash(name))
+}
+
 // OpenFile opens a file and returns a stream for its data.
 //
 // The path parts should be separated with slash '/' on any environments.
@@ -29,5 +38,9 @@ import (
 //
 // Deprecated: as of v2.4. Use os.Open on desktops and http.Get on browsers instead.
 func OpenFile(path string) (ReadSeekCloser, error) {
-	return os.Open(filepath.FromSlash(path))
+	return OpenFileSystem(osFS{}, path)
+}
+
+func defaultFileSystem() fs.FS {
+	return osFS{}
 }
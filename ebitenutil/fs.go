@@ -15,28 +15,92 @@
 package ebitenutil
 
 import (
+	"bytes"
 	"image"
+	"io"
 	"io/fs"
+	"path"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+// OpenFileSystem opens a file in the specified file system and returns a stream for its data.
+//
+// fsys can be any fs.FS like embed.FS, os.DirFS or fstest.MapFS.
+// OpenFileSystem works on any environments including browsers and mobiles.
+//
+// If the file opened by fsys is not seekable, its whole data is read into memory.
+func OpenFileSystem(fsys fs.FS, path string) (io.ReadSeekCloser, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if rsc, ok := f.(io.ReadSeekCloser); ok {
+		return rsc, nil
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	return newMemFile(path, b), nil
+}
+
 // NewImageFromFileSystem create an image from the specified file system.
 //
 // Image decoders must be imported when using NewImageFromReader. For example,
 // if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
-func NewImageFromFileSystem(fs fs.FS, path string) (*ebiten.Image, image.Image, error) {
-	file, err := fs.Open(path)
+func NewImageFromFileSystem(fsys fs.FS, path string) (*ebiten.Image, image.Image, error) {
+	file, err := OpenFileSystem(fsys, path)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer func() {
 		_ = file.Close()
 	}()
-	img, _, err := image.Decode(file)
-	if err != nil {
-		return nil, nil, err
+	return NewImageFromReader(file)
+}
+
+// memFile is an fs.File whose data is on memory.
+type memFile struct {
+	*bytes.Reader
+	name string
+}
+
+func newMemFile(name string, data []byte) *memFile {
+	return &memFile{
+		Reader: bytes.NewReader(data),
+		name:   name,
 	}
-	img2 := ebiten.NewImageFromImage(img)
-	return img2, img, nil
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	return f, nil
+}
+
+func (f *memFile) Name() string {
+	return path.Base(f.name)
+}
+
+func (f *memFile) Mode() fs.FileMode {
+	return 0444
+}
+
+func (f *memFile) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (f *memFile) IsDir() bool {
+	return false
+}
+
+func (f *memFile) Sys() any {
+	return nil
 }
@@ -19,7 +19,10 @@ import (
 	"image"
 	// `NewImageFromFileSystem` works without this importing, but this is not an expected thing (#2336).
 	_ "image/png"
+	"io"
+	"io/fs"
 	"testing"
+	"testing/fstest"
 
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
@@ -36,3 +39,59 @@ func TestNewImageFromFileSystem(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+// nonSeekableFS is a file system whose files are not io.Seeker.
+type nonSeekableFS struct {
+	fs fs.FS
+}
+
+type nonSeekableFile struct {
+	f fs.File
+}
+
+func (n *nonSeekableFS) Open(name string) (fs.File, error) {
+	f, err := n.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &nonSeekableFile{f: f}, nil
+}
+
+func (n *nonSeekableFile) Stat() (fs.FileInfo, error) {
+	return n.f.Stat()
+}
+
+func (n *nonSeekableFile) Read(buf []byte) (int, error) {
+	return n.f.Read(buf)
+}
+
+func (n *nonSeekableFile) Close() error {
+	return n.f.Close()
+}
+
+func TestOpenFileSystem(t *testing.T) {
+	mapFS := fstest.MapFS{
+		"dir/file.txt": &fstest.MapFile{
+			Data: []byte("Hello, Ebitengine"),
+		},
+	}
+	for _, fsys := range []fs.FS{mapFS, &nonSeekableFS{fs: mapFS}} {
+		f, err := ebitenutil.OpenFileSystem(fsys, "dir/file.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Seek(7, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "Ebitengine"; string(got) != want {
+			t.Errorf("got: %q, want: %q", string(got), want)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -30,14 +30,8 @@ import (
 // How to solve path depends on your environment. This varies on your desktop or web browser.
 // Note that this doesn't work on mobiles.
 //
-// For productions, instead of using NewImageFromFile, it is safer to embed your resources with go:embed.
+// For productions, instead of using NewImageFromFile, it is safer to embed your resources with go:embed
+// and use NewImageFromFileSystem.
 func NewImageFromFile(path string) (*ebiten.Image, image.Image, error) {
-	file, err := OpenFile(path)
-	if err != nil {
-		return nil, nil, err
-	}
-	defer func() {
-		_ = file.Close()
-	}()
-	return NewImageFromReader(file)
+	return NewImageFromFileSystem(defaultFileSystem(), path)
 }
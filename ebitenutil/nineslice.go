@@ -0,0 +1,138 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Insets represents the widths of the borders of an image in pixels.
+type Insets struct {
+	Left   int
+	Top    int
+	Right  int
+	Bottom int
+}
+
+// NineSliceMode represents how the edges and the center of a nine-slice image are filled.
+type NineSliceMode int
+
+const (
+	// NineSliceStretch stretches the edges and the center.
+	NineSliceStretch NineSliceMode = iota
+
+	// NineSliceTile repeats the edges and the center in their original size.
+	// The last tiles are cut at the right and bottom ends.
+	NineSliceTile
+)
+
+// DrawNineSliceOptions represents options for DrawNineSlice.
+type DrawNineSliceOptions struct {
+	// Mode is how the edges and the center are filled.
+	//
+	// The default (zero) value is NineSliceStretch.
+	Mode NineSliceMode
+
+	// ColorScale is a scale of color.
+	//
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+}
+
+// DrawNineSlice draws src on the rectangle dstRect of dst as a nine-slice (9-patch) image.
+//
+// src is split into 3x3 regions by insets. The corners are drawn in their original size,
+// and the edges and the center are stretched or tiled to fill dstRect.
+// If dstRect is smaller than the sum of the insets, the corners are shrunk proportionally.
+//
+// DrawNineSlice panics if any of insets is negative, or if the insets are bigger than src.
+//
+// If options is nil, the default setting is used.
+func DrawNineSlice(dst, src *ebiten.Image, insets Insets, dstRect image.Rectangle, options *DrawNineSliceOptions) {
+	if insets.Left < 0 || insets.Top < 0 || insets.Right < 0 || insets.Bottom < 0 {
+		panic(fmt.Sprintf("ebitenutil: insets at DrawNineSlice must not be negative but %+v", insets))
+	}
+	sb := src.Bounds()
+	if insets.Left+insets.Right > sb.Dx() || insets.Top+insets.Bottom > sb.Dy() {
+		panic(fmt.Sprintf("ebitenutil: insets at DrawNineSlice must fit in the source image size (%d, %d) but %+v", sb.Dx(), sb.Dy(), insets))
+	}
+
+	if options == nil {
+		options = &DrawNineSliceOptions{}
+	}
+
+	sxs := [4]int{sb.Min.X, sb.Min.X + insets.Left, sb.Max.X - insets.Right, sb.Max.X}
+	sys := [4]int{sb.Min.Y, sb.Min.Y + insets.Top, sb.Max.Y - insets.Bottom, sb.Max.Y}
+	dxs := nineSliceDestinations(dstRect.Min.X, dstRect.Max.X, insets.Left, insets.Right)
+	dys := nineSliceDestinations(dstRect.Min.Y, dstRect.Max.Y, insets.Top, insets.Bottom)
+
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale = options.ColorScale
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			sr := image.Rect(sxs[i], sys[j], sxs[i+1], sys[j+1])
+			dr := image.Rect(dxs[i], dys[j], dxs[i+1], dys[j+1])
+			if sr.Empty() || dr.Empty() {
+				continue
+			}
+			// The corners are always stretched, which is a no-op unless they are shrunk.
+			if options.Mode == NineSliceTile && (i == 1 || j == 1) {
+				drawTiled(dst, src, sr, dr, op)
+				continue
+			}
+			op.GeoM.Reset()
+			op.GeoM.Scale(float64(dr.Dx())/float64(sr.Dx()), float64(dr.Dy())/float64(sr.Dy()))
+			op.GeoM.Translate(float64(dr.Min.X), float64(dr.Min.Y))
+			dst.DrawImage(src.SubImage(sr).(*ebiten.Image), op)
+		}
+	}
+}
+
+// nineSliceDestinations returns the positions of the boundaries of the 3 regions between min and max.
+func nineSliceDestinations(min, max int, start, end int) [4]int {
+	size := max - min
+	if size <= 0 || start+end == 0 {
+		if size < 0 {
+			size = 0
+		}
+		return [4]int{min, min, min + size, min + size}
+	}
+	if size < start+end {
+		start = start * size / (start + end)
+		end = size - start
+	}
+	return [4]int{min, min + start, max - end, max}
+}
+
+func drawTiled(dst, src *ebiten.Image, sr, dr image.Rectangle, op *ebiten.DrawImageOptions) {
+	for y := dr.Min.Y; y < dr.Max.Y; y += sr.Dy() {
+		for x := dr.Min.X; x < dr.Max.X; x += sr.Dx() {
+			// Cut the last tiles at the ends.
+			r := sr
+			if w := dr.Max.X - x; w < r.Dx() {
+				r.Max.X = r.Min.X + w
+			}
+			if h := dr.Max.Y - y; h < r.Dy() {
+				r.Max.Y = r.Min.Y + h
+			}
+			op.GeoM.Reset()
+			op.GeoM.Translate(float64(x), float64(y))
+			dst.DrawImage(src.SubImage(r).(*ebiten.Image), op)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebitenutil_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+// nineSliceSourceColor is the color of the source image at (x, y).
+// R and G represent the region, and B represents the column.
+func nineSliceSourceColor(x, y int) color.RGBA {
+	return color.RGBA{R: byte(x/2) * 100, G: byte(y/2) * 100, B: byte(x) * 40, A: 0xff}
+}
+
+func newNineSliceSource() *ebiten.Image {
+	const size = 6
+	pix := make([]byte, 4*size*size)
+	for j := 0; j < size; j++ {
+		for i := 0; i < size; i++ {
+			c := nineSliceSourceColor(i, j)
+			idx := 4 * (j*size + i)
+			pix[idx] = c.R
+			pix[idx+1] = c.G
+			pix[idx+2] = c.B
+			pix[idx+3] = c.A
+		}
+	}
+	img := ebiten.NewImage(size, size)
+	img.WritePixels(pix)
+	return img
+}
+
+func TestDrawNineSlice(t *testing.T) {
+	src := newNineSliceSource()
+	insets := ebitenutil.Insets{Left: 2, Top: 2, Right: 2, Bottom: 2}
+
+	for _, mode := range []ebitenutil.NineSliceMode{ebitenutil.NineSliceStretch, ebitenutil.NineSliceTile} {
+		dst := ebiten.NewImage(24, 24)
+		ebitenutil.DrawNineSlice(dst, src, insets, image.Rect(2, 2, 22, 22), &ebitenutil.DrawNineSliceOptions{
+			Mode: mode,
+		})
+
+		// The corners are not scaled.
+		for _, p := range []image.Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {4, 0}, {5, 1}, {0, 5}, {5, 5}} {
+			sx, sy := p.X, p.Y
+			dx, dy := sx+2, sy+2
+			if sx >= 4 {
+				dx = sx + 16
+			}
+			if sy >= 4 {
+				dy = sy + 16
+			}
+			if got, want := dst.At(dx, dy), nineSliceSourceColor(sx, sy); got != want {
+				t.Errorf("mode: %d, dst.At(%d, %d): got: %v, want: %v", mode, dx, dy, got, want)
+			}
+		}
+
+		// The top edge is stretched or tiled.
+		for x := 4; x < 20; x++ {
+			sx := 2 + (x-4)/8
+			if mode == ebitenutil.NineSliceTile {
+				sx = 2 + (x-4)%2
+			}
+			if got, want := dst.At(x, 2), nineSliceSourceColor(sx, 0); got != want {
+				t.Errorf("mode: %d, dst.At(%d, 2): got: %v, want: %v", mode, x, got, want)
+			}
+		}
+
+		// Outside of the rectangle is not drawn.
+		if got, want := dst.At(1, 1), (color.RGBA{}); got != want {
+			t.Errorf("mode: %d, dst.At(1, 1): got: %v, want: %v", mode, got, want)
+		}
+	}
+}
+
+func TestDrawNineSliceInvalidInsets(t *testing.T) {
+	src := newNineSliceSource()
+	dst := ebiten.NewImage(24, 24)
+
+	for _, insets := range []ebitenutil.Insets{
+		{Left: -1, Top: 2, Right: 2, Bottom: 2},
+		{Left: 2, Top: 2, Right: 2, Bottom: -1},
+		{Left: 4, Top: 2, Right: 3, Bottom: 2},
+		{Left: 2, Top: 6, Right: 2, Bottom: 1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("DrawNineSlice with insets %+v must panic", insets)
+				}
+			}()
+			ebitenutil.DrawNineSlice(dst, src, insets, image.Rect(2, 2, 22, 22), nil)
+		}()
+	}
+
+	// Insets that cover the whole source image are valid.
+	ebitenutil.DrawNineSlice(dst, src, ebitenutil.Insets{Left: 3, Top: 6, Right: 3}, image.Rect(2, 2, 22, 22), nil)
+}
+
+func TestDrawNineSliceEmptyRect(t *testing.T) {
+	src := newNineSliceSource()
+
+	for _, insets := range []ebitenutil.Insets{
+		{},
+		{Left: 2, Top: 2, Right: 2, Bottom: 2},
+	} {
+		for _, r := range []image.Rectangle{
+			{},
+			{Min: image.Pt(2, 2), Max: image.Pt(2, 22)},
+			// A non-canonical rectangle has a negative size.
+			{Min: image.Pt(22, 22), Max: image.Pt(2, 2)},
+		} {
+			dst := ebiten.NewImage(24, 24)
+			ebitenutil.DrawNineSlice(dst, src, insets, r, nil)
+			for j := 0; j < 24; j++ {
+				for i := 0; i < 24; i++ {
+					if got, want := dst.At(i, j), (color.RGBA{}); got != want {
+						t.Fatalf("insets: %+v, rect: %v, dst.At(%d, %d): got: %v, want: %v", insets, r, i, j, got, want)
+					}
+				}
+			}
+		}
+	}
+}
@@ -23,11 +23,14 @@ import (
 )
 
 const (
+	_ATTR_CONVERTED           = 0x02
 	_ATTR_TARGET_CONVERTED    = 0x01
 	_ATTR_TARGET_NOTCONVERTED = 0x03
 
 	_CFS_CANDIDATEPOS = 0x0040
 
+	_CPS_CANCEL = 0x0004
+
 	_GCS_COMPATTR   = 0x0010
 	_GCS_COMPCLAUSE = 0x0020
 	_GCS_COMPSTR    = 0x0008
@@ -37,6 +40,8 @@ const (
 
 	_ISC_SHOWUICOMPOSITIONWINDOW = 0x80000000
 
+	_NI_COMPOSITIONSTR = 0x0015
+
 	_UNICODE_NOCHAR = 0xffff
 
 	_WM_CHAR            = 0x0102
@@ -75,6 +80,7 @@ var (
 
 	procImmGetCompositionStringW = imm32.NewProc("ImmGetCompositionStringW")
 	procImmGetContext            = imm32.NewProc("ImmGetContext")
+	procImmNotifyIME             = imm32.NewProc("ImmNotifyIME")
 	procImmReleaseContext        = imm32.NewProc("ImmReleaseContext")
 	procImmSetCandidateWindow    = imm32.NewProc("ImmSetCandidateWindow")
 
@@ -111,6 +117,17 @@ func _ImmGetContext(unnamedParam1 windows.HWND) _HIMC {
 	return _HIMC(r)
 }
 
+func _ImmNotifyIME(unnamedParam1 _HIMC, dwAction, dwIndex, dwValue uint32) error {
+	r, _, e := procImmNotifyIME.Call(uintptr(unnamedParam1), uintptr(dwAction), uintptr(dwIndex), uintptr(dwValue))
+	if int32(r) == 0 {
+		if e != nil && e != windows.ERROR_SUCCESS {
+			return fmt.Errorf("textinput: ImmNotifyIME failed: %w", e)
+		}
+		return fmt.Errorf("textinput: ImmNotifyIME returned 0")
+	}
+	return nil
+}
+
 func _ImmReleaseContext(unnamedParam1 windows.HWND, unnamedParam2 _HIMC) error {
 	r, _, e := procImmReleaseContext.Call(uintptr(unnamedParam1), uintptr(unnamedParam2))
 	if int32(r) == 0 {
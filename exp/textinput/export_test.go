@@ -0,0 +1,25 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+func TruncateUTF16ForTesting(text string, maxLength int) string {
+	return truncateUTF16(text, maxLength)
+}
+
+// NewSessionForTesting creates a session, and returns its channel and functions to send a state and to cancel it.
+func NewSessionForTesting(options *StartOptions) (states chan State, send func(State), cancel func()) {
+	s := newSession(options)
+	return s.ch, s.trySend, s.cancel
+}
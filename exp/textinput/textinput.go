@@ -15,7 +15,7 @@
 // Package textinput provides a text-inputting controller.
 // This package is experimental and the API might be changed in the future.
 //
-// This package is supported by macOS and Web browsers so far.
+// This package is supported by Windows, macOS and Web browsers so far.
 package textinput
 
 import (
@@ -37,13 +37,129 @@ type State struct {
 	// CompositionSelectionStartInBytes represents the end position of the selection in bytes.
 	CompositionSelectionEndInBytes int
 
+	// Clauses represents the clauses in the composition text.
+	// Some input methods divide a composition text into multiple clauses and convert them one by one.
+	//
+	// Clauses is available only when Committed is false.
+	// If the input method doesn't provide clause information, Clauses has a single clause covering the whole text.
+	Clauses []Clause
+
 	// Committed reports whether the current Text is the settled text.
 	Committed bool
 
+	// Phase represents the phase of the composition.
+	// Phase is CompositionPhaseComposing only while an input method is actually composing a text,
+	// so a game can style the composition text only in this phase.
+	Phase CompositionPhase
+
 	// Error is an error that happens during text inputting.
 	Error error
 }
 
+// CompositionPhase represents the phase of a composition by an input method.
+type CompositionPhase int
+
+const (
+	// CompositionPhaseNone represents that no composition is in progress.
+	// For example, a text suggested by text completion on mobile browsers is not committed but in this phase.
+	CompositionPhaseNone CompositionPhase = iota
+
+	// CompositionPhaseComposing represents that an input method is composing a text.
+	CompositionPhaseComposing
+
+	// CompositionPhaseCommitted represents that the text is committed.
+	CompositionPhaseCommitted
+)
+
+// ClauseStyle represents the conversion state of a clause in a composition text.
+type ClauseStyle int
+
+const (
+	// ClauseStyleInput represents a clause that is not converted yet.
+	ClauseStyleInput ClauseStyle = iota
+
+	// ClauseStyleConverted represents a converted clause.
+	ClauseStyleConverted
+
+	// ClauseStyleTarget represents the clause being converted.
+	// A game should typically highlight this clause.
+	ClauseStyleTarget
+)
+
+// Clause represents a clause in a composition text.
+type Clause struct {
+	// StartInBytes represents the start position of the clause in bytes.
+	StartInBytes int
+
+	// EndInBytes represents the end position of the clause in bytes.
+	EndInBytes int
+
+	// Style represents the conversion state of the clause.
+	Style ClauseStyle
+}
+
+// InputMode represents a hint for the kind of a virtual keyboard.
+type InputMode int
+
+const (
+	// InputModeText is the default mode showing a standard keyboard.
+	InputModeText InputMode = iota
+
+	// InputModeNumeric shows a keyboard for digits.
+	InputModeNumeric
+
+	// InputModeDecimal shows a keyboard for decimal numbers including a decimal separator.
+	InputModeDecimal
+
+	// InputModeTel shows a keyboard for telephone numbers.
+	InputModeTel
+
+	// InputModeEmail shows a keyboard for email addresses.
+	InputModeEmail
+
+	// InputModeURL shows a keyboard for URLs.
+	InputModeURL
+)
+
+// StartOptions represents options for StartWithOptions.
+type StartOptions struct {
+	// MaxLength is the maximum length of the text in each State, in UTF-16 code units.
+	// This is the same unit as the HTML maxlength attribute.
+	// A longer text is truncated without splitting a surrogate pair.
+	//
+	// MaxLength limits each State independently. The total length of the texts committed in one session
+	// can exceed MaxLength, so a caller accumulating the committed texts should limit the total length by itself.
+	//
+	// The default (zero) value means no limit.
+	MaxLength int
+
+	// Password represents whether the text is a password.
+	// In the password mode, a text being composed is not sent, so a partial text is never echoed on the screen.
+	// State.Text still has the actual characters.
+	//
+	// On Web browsers, a password input element is used so that the browser handles the text securely.
+	// Every input is committed immediately there.
+	//
+	// The default (zero) value is false.
+	Password bool
+
+	// InputMode is a hint for the kind of a virtual keyboard.
+	// InputMode is used only on Web browsers on mobile devices, and corresponds to the HTML inputmode attribute.
+	//
+	// The default (zero) value is InputModeText.
+	InputMode InputMode
+
+	// Multiline represents whether the text can have multiple lines.
+	// In the multiline mode, Enter inserts a line break into State.Text instead of committing the text,
+	// and State.Committed is set only when Ctrl+Enter (or Command+Enter on macOS) is pressed.
+	//
+	// Multiline is used only on Web browsers and Windows.
+	// On Windows, every character including a line break is committed immediately as usual.
+	//
+	// The default (zero) value is false.
+	Multiline bool
+}
+
 // Start starts text inputting.
 // Start returns a channel to send the state repeatedly, and a function to end the text inputting.
 //
@@ -51,24 +167,73 @@ type State struct {
 //
 // Start returns nil and nil if the current environment doesn't support this package.
 func Start(x, y int) (states chan State, close func()) {
+	return StartWithOptions(x, y, nil)
+}
+
+// StartWithOptions starts text inputting with the given options.
+//
+// If options is nil, the default setting is used.
+//
+// StartWithOptions returns nil and nil if the current environment doesn't support this package.
+func StartWithOptions(x, y int, options *StartOptions) (states chan State, close func()) {
 	cx, cy := ui.Get().LogicalPositionToClientPositionInNativePixels(float64(x), float64(y))
-	return theTextInput.Start(int(cx), int(cy))
+	return theTextInput.Start(int(cx), int(cy), options)
+}
+
+// Cancel cancels the current text inputting and discards the composition text.
+// A State with an empty Text and Committed=true is sent as the last state, so that a game can drop the composition text.
+//
+// Cancel does nothing if text inputting is not started.
+func Cancel() {
+	theTextInput.Cancel()
 }
 
 func convertUTF16CountToByteCount(text string, c int) int {
 	return len(string(utf16.Decode(utf16.Encode([]rune(text))[:c])))
 }
 
+// truncateUTF16 returns the longest prefix of text whose length in UTF-16 code units is at most maxLength.
+// truncateUTF16 never splits a surrogate pair.
+func truncateUTF16(text string, maxLength int) string {
+	var n int
+	for i, r := range text {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+		if n > maxLength {
+			return text[:i]
+		}
+	}
+	return text
+}
+
 type session struct {
-	ch   chan State
-	done chan struct{}
+	ch        chan State
+	done      chan struct{}
+	maxLength int
+	password  bool
+	multiline bool
 }
 
-func newSession() *session {
-	return &session{
+func newSession(options *StartOptions) *session {
+	s := &session{
 		ch:   make(chan State, 1),
 		done: make(chan struct{}),
 	}
+	s.setOptions(options)
+	return s
+}
+
+// setOptions updates the options of the session.
+func (s *session) setOptions(options *StartOptions) {
+	if options == nil {
+		options = &StartOptions{}
+	}
+	s.maxLength = options.MaxLength
+	s.password = options.Password
+	s.multiline = options.Multiline
 }
 
 func (s *session) end() {
@@ -80,7 +245,49 @@ func (s *session) end() {
 	close(s.done)
 }
 
+// cancel sends an empty committed state and ends the session.
+func (s *session) cancel() {
+	s.trySend(State{Committed: true})
+	s.end()
+}
+
 func (s *session) trySend(state State) {
+	if s.password && !state.Committed && state.Error == nil {
+		return
+	}
+	if state.Committed {
+		state.Phase = CompositionPhaseCommitted
+	}
+	if s.maxLength > 0 {
+		state.Text = truncateUTF16(state.Text, s.maxLength)
+		if state.CompositionSelectionStartInBytes > len(state.Text) {
+			state.CompositionSelectionStartInBytes = len(state.Text)
+		}
+		if state.CompositionSelectionEndInBytes > len(state.Text) {
+			state.CompositionSelectionEndInBytes = len(state.Text)
+		}
+		clauses := state.Clauses
+		state.Clauses = nil
+		for _, c := range clauses {
+			if c.StartInBytes >= len(state.Text) {
+				break
+			}
+			if c.EndInBytes > len(state.Text) {
+				c.EndInBytes = len(state.Text)
+			}
+			state.Clauses = append(state.Clauses, c)
+		}
+	}
+	if !state.Committed && state.Error == nil && len(state.Clauses) == 0 && state.Text != "" {
+		state.Clauses = []Clause{
+			{
+				StartInBytes: 0,
+				EndInBytes:   len(state.Text),
+				Style:        ClauseStyleInput,
+			},
+		}
+	}
+
 	for {
 		select {
 		case s.ch <- state:
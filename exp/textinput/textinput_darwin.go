@@ -33,17 +33,37 @@ type textInput struct {
 
 var theTextInput textInput
 
-func (t *textInput) Start(x, y int) (chan State, func()) {
+func (t *textInput) Start(x, y int, options *StartOptions) (chan State, func()) {
 	var session *session
 	ui.Get().RunOnMainThread(func() {
 		t.end()
 		start(x, y)
-		session = newSession()
+		session = newSession(options)
 		t.session = session
 	})
 	return session.ch, session.end
 }
 
+func (t *textInput) Cancel() {
+	ui.Get().RunOnMainThread(func() {
+		if t.session == nil {
+			return
+		}
+
+		// Detach the session first so that resigning the first responder doesn't send another state.
+		s := t.session
+		t.session = nil
+
+		client := getTextInputClient()
+		client.Send(selInputContext).Send(selDiscardMarkedText)
+		client.Send(selUnmarkText)
+		window := idNSApplication.Send(selSharedApplication).Send(selMainWindow)
+		window.Send(selMakeFirstResponder, window.Send(selContentView))
+
+		s.cancel()
+	})
+}
+
 //export ebitengine_textinput_update
 func ebitengine_textinput_update(text *C.char, start, end C.int, committed C.int) {
 	theTextInput.update(C.GoString(text), int(start), int(end), committed != 0)
@@ -53,11 +73,17 @@ func (t *textInput) update(text string, start, end int, committed bool) {
 	if t.session != nil {
 		startInBytes := convertUTF16CountToByteCount(text, start)
 		endInBytes := convertUTF16CountToByteCount(text, end)
+		// An uncommitted text always comes from the marked text.
+		phase := CompositionPhaseComposing
+		if committed {
+			phase = CompositionPhaseCommitted
+		}
 		t.session.trySend(State{
 			Text:                             text,
 			CompositionSelectionStartInBytes: startInBytes,
 			CompositionSelectionEndInBytes:   endInBytes,
 			Committed:                        committed,
+			Phase:                            phase,
 		})
 	}
 	if committed {
@@ -81,12 +107,15 @@ var (
 	selAddSubview         = objc.RegisterName("addSubview:")
 	selAlloc              = objc.RegisterName("alloc")
 	selContentView        = objc.RegisterName("contentView")
+	selDiscardMarkedText  = objc.RegisterName("discardMarkedText")
 	selFrame              = objc.RegisterName("frame")
 	selInit               = objc.RegisterName("init")
+	selInputContext       = objc.RegisterName("inputContext")
 	selMainWindow         = objc.RegisterName("mainWindow")
 	selMakeFirstResponder = objc.RegisterName("makeFirstResponder:")
 	selSetFrame           = objc.RegisterName("setFrame:")
 	selSharedApplication  = objc.RegisterName("sharedApplication")
+	selUnmarkText         = objc.RegisterName("unmarkText")
 
 	idNSApplication = objc.ID(objc.GetClass("NSApplication"))
 )
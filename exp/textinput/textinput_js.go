@@ -35,21 +35,63 @@ func init() {
 
 type textInput struct {
 	textareaElement js.Value
+	passwordElement js.Value
+
+	// element is the current element for text inputting, either textareaElement or passwordElement.
+	element js.Value
 
 	session *session
+
+	// composing reports whether the browser is between compositionstart and compositionend events.
+	composing bool
 }
 
 var theTextInput textInput
 
 func (t *textInput) init() {
-	t.textareaElement = document.Call("createElement", "textarea")
-	t.textareaElement.Set("id", "ebitengine-textinput")
-	t.textareaElement.Set("autocapitalize", "off")
-	t.textareaElement.Set("spellcheck", false)
-	t.textareaElement.Set("translate", "no")
+	t.textareaElement = t.createElement("textarea", "ebitengine-textinput")
 	t.textareaElement.Set("wrap", "off")
 
-	style := t.textareaElement.Get("style")
+	t.passwordElement = t.createElement("input", "ebitengine-textinput-password")
+	t.passwordElement.Set("type", "password")
+	t.passwordElement.Set("autocomplete", "current-password")
+
+	t.element = t.textareaElement
+
+	js.Global().Call("eval", `
+// Process the textarea element under user-interaction events.
+// This is due to an iOS Safari restriction (#2898).
+let handler = (e) => {
+	if (window._ebitengine_textinput_x === undefined || window._ebitengine_textinput_y === undefined) {
+		return;
+	}
+	let textarea = document.getElementById(window._ebitengine_textinput_id);
+	textarea.value = '';
+	textarea.focus();
+	textarea.style.left = _ebitengine_textinput_x + 'px';
+	textarea.style.top = _ebitengine_textinput_y + 'px';
+	window._ebitengine_textinput_x = undefined;
+	window._ebitengine_textinput_y = undefined;
+	window._ebitengine_textinput_id = undefined;
+	window._ebitengine_textinput_ready = true;
+};
+
+let body = window.document.body;
+body.addEventListener("mouseup", handler);
+body.addEventListener("touchend", handler);
+body.addEventListener("keyup", handler);`)
+
+	// TODO: What about other events like wheel?
+}
+
+func (t *textInput) createElement(tagName string, id string) js.Value {
+	el := document.Call("createElement", tagName)
+	el.Set("id", id)
+	el.Set("autocapitalize", "off")
+	el.Set("spellcheck", false)
+	el.Set("translate", "no")
+
+	style := el.Get("style")
 	style.Set("position", "absolute")
 	style.Set("left", "0")
 	style.Set("top", "0")
@@ -62,23 +104,39 @@ func (t *textInput) init() {
 	style.Set("width", "1px")
 	style.Set("height", "1px")
 
-	t.textareaElement.Call("addEventListener", "compositionend", js.FuncOf(func(this js.Value, args []js.Value) any {
-		t.trySend(true)
+	el.Call("addEventListener", "compositionstart", js.FuncOf(func(this js.Value, args []js.Value) any {
+		t.composing = true
+		t.trySend(false)
+		return nil
+	}))
+	el.Call("addEventListener", "compositionupdate", js.FuncOf(func(this js.Value, args []js.Value) any {
+		// The value is not updated yet here. The state is sent at the following input event.
+		t.composing = true
 		return nil
 	}))
-	t.textareaElement.Call("addEventListener", "focusout", js.FuncOf(func(this js.Value, args []js.Value) any {
+	el.Call("addEventListener", "compositionend", js.FuncOf(func(this js.Value, args []js.Value) any {
+		t.composing = false
+		// In the multiline mode, the text is committed only by an explicit signal.
+		t.trySend(!t.multiline())
+		return nil
+	}))
+	el.Call("addEventListener", "focusout", js.FuncOf(func(this js.Value, args []js.Value) any {
 		if t.session != nil {
 			t.session.end()
 			t.session = nil
 		}
 		return nil
 	}))
-	t.textareaElement.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+	el.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
 		e := args[0]
 		if e.Get("code").String() == "Tab" {
 			e.Call("preventDefault")
 		}
 		if e.Get("code").String() == "Enter" || e.Get("key").String() == "Enter" {
+			if t.multiline() && !e.Get("isComposing").Bool() && (e.Get("ctrlKey").Bool() || e.Get("metaKey").Bool()) {
+				e.Call("preventDefault")
+				t.trySend(true)
+			}
 			// Ignore Enter key to avoid ebiten.IsKeyPressed(ebiten.KeyEnter) unexpectedly becomes true, especially for iOS Safari.
 			return nil
 		}
@@ -87,73 +145,85 @@ func (t *textInput) init() {
 		}
 		return nil
 	}))
-	t.textareaElement.Call("addEventListener", "keyup", js.FuncOf(func(this js.Value, args []js.Value) any {
+	el.Call("addEventListener", "keyup", js.FuncOf(func(this js.Value, args []js.Value) any {
 		e := args[0]
 		if !e.Get("isComposing").Bool() {
 			ui.Get().UpdateInputFromEvent(e)
 		}
 		return nil
 	}))
-	t.textareaElement.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
+	el.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
 		e := args[0]
 		if e.Get("isComposing").Bool() {
 			t.trySend(false)
 			return nil
 		}
-		if e.Get("inputType").String() == "insertLineBreak" {
+		if t.element.Equal(t.passwordElement) {
+			// Commit every input so that a partial text is never echoed as a composition text.
 			t.trySend(true)
 			return nil
 		}
+		if e.Get("inputType").String() == "insertLineBreak" {
+			// In the multiline mode, a line break is just a part of the text.
+			t.trySend(!t.multiline())
+			return nil
+		}
 		if e.Get("inputType").String() == "insertText" && e.Get("data").Equal(js.Null()) {
 			// When a new line is inserted, the 'data' property might be null.
-			t.trySend(true)
+			t.trySend(!t.multiline())
 			return nil
 		}
 		// Though `isComposing` is false, send the text as being not committed for text completion on mobile browsers.
 		t.trySend(false)
 		return nil
 	}))
-	t.textareaElement.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+	el.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
 		t.trySend(true)
 		return nil
 	}))
-	body.Call("appendChild", t.textareaElement)
+	body.Call("appendChild", el)
 
-	js.Global().Call("eval", `
-// Process the textarea element under user-interaction events.
-// This is due to an iOS Safari restriction (#2898).
-let handler = (e) => {
-	if (window._ebitengine_textinput_x === undefined || window._ebitengine_textinput_y === undefined) {
-		return;
+	return el
+}
+
+func (t *textInput) Start(x, y int, options *StartOptions) (chan State, func()) {
+	if !t.element.Truthy() {
+		return nil, nil
 	}
-	let textarea = document.getElementById("ebitengine-textinput");
-	textarea.value = '';
-	textarea.focus();
-	textarea.style.left = _ebitengine_textinput_x + 'px';
-	textarea.style.top = _ebitengine_textinput_y + 'px';
-	window._ebitengine_textinput_x = undefined;
-	window._ebitengine_textinput_y = undefined;
-	window._ebitengine_textinput_ready = true;
-};
 
-let body = window.document.body;
-body.addEventListener("mouseup", handler);
-body.addEventListener("touchend", handler);
-body.addEventListener("keyup", handler);`)
+	element := t.textareaElement
+	if options != nil && options.Password {
+		element = t.passwordElement
+	}
+	if !t.element.Equal(element) {
+		if t.session != nil {
+			t.session.end()
+			t.session = nil
+		}
+		t.element.Call("blur")
+		t.element = element
+	}
 
-	// TODO: What about other events like wheel?
-}
+	// Let the browser block the overflow too.
+	if options != nil && options.MaxLength > 0 {
+		t.element.Set("maxLength", options.MaxLength)
+	} else {
+		t.element.Call("removeAttribute", "maxlength")
+	}
 
-func (t *textInput) Start(x, y int) (chan State, func()) {
-	if !t.textareaElement.Truthy() {
-		return nil, nil
+	// inputmode must be set before the element is focused, or a virtual keyboard might not be updated.
+	// Note that the element is focused in the user-interaction handler on iOS Safari.
+	if options != nil && options.InputMode != InputModeText {
+		t.element.Set("inputMode", inputModeToString(options.InputMode))
+	} else {
+		t.element.Call("removeAttribute", "inputmode")
 	}
 
 	if js.Global().Get("_ebitengine_textinput_ready").Truthy() {
 		if t.session != nil {
 			t.session.end()
 		}
-		s := newSession()
+		s := newSession(options)
 		t.session = s
 		js.Global().Get("window").Set("_ebitengine_textinput_ready", js.Undefined())
 		return s.ch, s.end
@@ -161,16 +231,19 @@ func (t *textInput) Start(x, y int) (chan State, func()) {
 
 	// If a textarea is focused, create a session immediately.
 	// A virtual keyboard should already be shown on mobile browsers.
-	if document.Get("activeElement").Equal(t.textareaElement) {
-		t.textareaElement.Set("value", "")
-		t.textareaElement.Call("focus")
-		style := t.textareaElement.Get("style")
+	if document.Get("activeElement").Equal(t.element) {
+		t.element.Set("value", "")
+		t.element.Call("focus")
+		style := t.element.Get("style")
 		style.Set("left", fmt.Sprintf("%dpx", x))
 		style.Set("top", fmt.Sprintf("%dpx", y))
 
 		if t.session == nil {
-			s := newSession()
+			s := newSession(options)
 			t.session = s
+		} else {
+			// The options might be changed from the previous Start call.
+			t.session.setOptions(options)
 		}
 		return t.session.ch, t.session.end
 	}
@@ -184,25 +257,71 @@ func (t *textInput) Start(x, y int) (chan State, func()) {
 	// Assuming Start is called every tick, defer the starting process to the next user-interaction event.
 	js.Global().Get("window").Set("_ebitengine_textinput_x", x)
 	js.Global().Get("window").Set("_ebitengine_textinput_y", y)
+	js.Global().Get("window").Set("_ebitengine_textinput_id", t.element.Get("id"))
 	return nil, nil
 }
 
+func (t *textInput) Cancel() {
+	if t.session == nil {
+		return
+	}
+
+	// Detach the session first so that the compositionend and focusout events caused by blurring don't send another state.
+	s := t.session
+	t.session = nil
+	t.composing = false
+	t.element.Set("value", "")
+	t.element.Call("blur")
+	s.cancel()
+}
+
+func inputModeToString(mode InputMode) string {
+	switch mode {
+	case InputModeText:
+		return "text"
+	case InputModeNumeric:
+		return "numeric"
+	case InputModeDecimal:
+		return "decimal"
+	case InputModeTel:
+		return "tel"
+	case InputModeEmail:
+		return "email"
+	case InputModeURL:
+		return "url"
+	default:
+		panic(fmt.Sprintf("textinput: unexpected input mode: %d", mode))
+	}
+}
+
+func (t *textInput) multiline() bool {
+	return t.session != nil && t.session.multiline
+}
+
 func (t *textInput) trySend(committed bool) {
 	if t.session == nil {
 		return
 	}
 
-	textareaValue := t.textareaElement.Get("value").String()
-	start := t.textareaElement.Get("selectionStart").Int()
-	end := t.textareaElement.Get("selectionEnd").Int()
+	textareaValue := t.element.Get("value").String()
+	start := t.element.Get("selectionStart").Int()
+	end := t.element.Get("selectionEnd").Int()
 	startInBytes := convertUTF16CountToByteCount(textareaValue, start)
 	endInBytes := convertUTF16CountToByteCount(textareaValue, end)
 
+	phase := CompositionPhaseNone
+	if committed {
+		phase = CompositionPhaseCommitted
+	} else if t.composing {
+		phase = CompositionPhaseComposing
+	}
+
 	t.session.trySend(State{
 		Text:                             textareaValue,
 		CompositionSelectionStartInBytes: startInBytes,
 		CompositionSelectionEndInBytes:   endInBytes,
 		Committed:                        committed,
+		Phase:                            phase,
 	})
 
 	if committed {
@@ -210,6 +329,6 @@ func (t *textInput) trySend(committed bool) {
 			t.session.end()
 			t.session = nil
 		}
-		t.textareaElement.Set("value", "")
+		t.element.Set("value", "")
 	}
 }
@@ -20,6 +20,9 @@ type textInput struct{}
 
 var theTextInput textInput
 
-func (t *textInput) Start(x, y int) (chan State, func()) {
+func (t *textInput) Start(x, y int, options *StartOptions) (chan State, func()) {
 	return nil, nil
 }
+
+func (t *textInput) Cancel() {
+}
@@ -0,0 +1,350 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+)
+
+func TestTruncateUTF16(t *testing.T) {
+	cases := []struct {
+		In        string
+		MaxLength int
+		Out       string
+	}{
+		{
+			In:        "abc",
+			MaxLength: 3,
+			Out:       "abc",
+		},
+		{
+			In:        "abc",
+			MaxLength: 2,
+			Out:       "ab",
+		},
+		{
+			In:        "あいう",
+			MaxLength: 2,
+			Out:       "あい",
+		},
+		{
+			// U+1F600 is a surrogate pair in UTF-16.
+			In:        "ab\U0001F600",
+			MaxLength: 4,
+			Out:       "ab\U0001F600",
+		},
+		{
+			// Truncating at 3 would split the surrogate pair. Drop the whole character.
+			In:        "ab\U0001F600",
+			MaxLength: 3,
+			Out:       "ab",
+		},
+		{
+			In:        "\U0001F600\U0001F600",
+			MaxLength: 3,
+			Out:       "\U0001F600",
+		},
+		{
+			In:        "\U0001F600",
+			MaxLength: 1,
+			Out:       "",
+		},
+	}
+	for _, c := range cases {
+		if got := textinput.TruncateUTF16ForTesting(c.In, c.MaxLength); got != c.Out {
+			t.Errorf("TruncateUTF16ForTesting(%q, %d): got: %q, want: %q", c.In, c.MaxLength, got, c.Out)
+		}
+	}
+}
+
+// receive returns the state in the channel if exists.
+func receive(ch chan textinput.State) (textinput.State, bool) {
+	select {
+	case s, ok := <-ch:
+		return s, ok
+	default:
+		return textinput.State{}, false
+	}
+}
+
+func TestSessionSend(t *testing.T) {
+	errTest := errors.New("test")
+
+	cases := []struct {
+		Name     string
+		Options  *textinput.StartOptions
+		In       textinput.State
+		Out      textinput.State
+		Received bool
+	}{
+		{
+			Name: "composing",
+			In: textinput.State{
+				Text:  "abc",
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			// The default clause covers the whole text.
+			Out: textinput.State{
+				Text: "abc",
+				Clauses: []textinput.Clause{
+					{StartInBytes: 0, EndInBytes: 3, Style: textinput.ClauseStyleInput},
+				},
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			Received: true,
+		},
+		{
+			Name: "composing with clauses",
+			In: textinput.State{
+				Text: "あいう",
+				Clauses: []textinput.Clause{
+					{StartInBytes: 0, EndInBytes: 6, Style: textinput.ClauseStyleConverted},
+					{StartInBytes: 6, EndInBytes: 9, Style: textinput.ClauseStyleTarget},
+				},
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			Out: textinput.State{
+				Text: "あいう",
+				Clauses: []textinput.Clause{
+					{StartInBytes: 0, EndInBytes: 6, Style: textinput.ClauseStyleConverted},
+					{StartInBytes: 6, EndInBytes: 9, Style: textinput.ClauseStyleTarget},
+				},
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			Received: true,
+		},
+		{
+			Name: "empty composition",
+			In:   textinput.State{},
+			// No default clause is added for an empty text.
+			Out:      textinput.State{},
+			Received: true,
+		},
+		{
+			Name: "committed",
+			In: textinput.State{
+				Text:      "abc",
+				Committed: true,
+			},
+			Out: textinput.State{
+				Text:      "abc",
+				Committed: true,
+				Phase:     textinput.CompositionPhaseCommitted,
+			},
+			Received: true,
+		},
+		{
+			Name: "committed after composing",
+			In: textinput.State{
+				Text:      "abc",
+				Committed: true,
+				Phase:     textinput.CompositionPhaseComposing,
+			},
+			Out: textinput.State{
+				Text:      "abc",
+				Committed: true,
+				Phase:     textinput.CompositionPhaseCommitted,
+			},
+			Received: true,
+		},
+		{
+			Name: "error",
+			In: textinput.State{
+				Text:  "abc",
+				Error: errTest,
+			},
+			Out: textinput.State{
+				Text:  "abc",
+				Error: errTest,
+			},
+			Received: true,
+		},
+		{
+			Name:    "password composing",
+			Options: &textinput.StartOptions{Password: true},
+			In: textinput.State{
+				Text:  "abc",
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			// A composition text is never sent in the password mode.
+			Received: false,
+		},
+		{
+			Name:    "password not composing",
+			Options: &textinput.StartOptions{Password: true},
+			In: textinput.State{
+				Text: "abc",
+			},
+			Received: false,
+		},
+		{
+			Name:    "password committed",
+			Options: &textinput.StartOptions{Password: true},
+			In: textinput.State{
+				Text:      "abc",
+				Committed: true,
+			},
+			Out: textinput.State{
+				Text:      "abc",
+				Committed: true,
+				Phase:     textinput.CompositionPhaseCommitted,
+			},
+			Received: true,
+		},
+		{
+			Name:    "password error",
+			Options: &textinput.StartOptions{Password: true},
+			In: textinput.State{
+				Error: errTest,
+			},
+			Out: textinput.State{
+				Error: errTest,
+			},
+			Received: true,
+		},
+		{
+			Name:    "max length",
+			Options: &textinput.StartOptions{MaxLength: 2},
+			In: textinput.State{
+				Text:                             "あいう",
+				CompositionSelectionStartInBytes: 6,
+				CompositionSelectionEndInBytes:   9,
+				Clauses: []textinput.Clause{
+					{StartInBytes: 0, EndInBytes: 3, Style: textinput.ClauseStyleConverted},
+					{StartInBytes: 3, EndInBytes: 6, Style: textinput.ClauseStyleTarget},
+					{StartInBytes: 6, EndInBytes: 9, Style: textinput.ClauseStyleInput},
+				},
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			// The clauses after the text are dropped, and the selection is clamped.
+			Out: textinput.State{
+				Text:                             "あい",
+				CompositionSelectionStartInBytes: 6,
+				CompositionSelectionEndInBytes:   6,
+				Clauses: []textinput.Clause{
+					{StartInBytes: 0, EndInBytes: 3, Style: textinput.ClauseStyleConverted},
+					{StartInBytes: 3, EndInBytes: 6, Style: textinput.ClauseStyleTarget},
+				},
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			Received: true,
+		},
+		{
+			Name:    "max length in a clause",
+			Options: &textinput.StartOptions{MaxLength: 1},
+			In: textinput.State{
+				Text: "あいう",
+				Clauses: []textinput.Clause{
+					{StartInBytes: 0, EndInBytes: 6, Style: textinput.ClauseStyleTarget},
+					{StartInBytes: 6, EndInBytes: 9, Style: textinput.ClauseStyleInput},
+				},
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			// The clause across the end is cut.
+			Out: textinput.State{
+				Text: "あ",
+				Clauses: []textinput.Clause{
+					{StartInBytes: 0, EndInBytes: 3, Style: textinput.ClauseStyleTarget},
+				},
+				Phase: textinput.CompositionPhaseComposing,
+			},
+			Received: true,
+		},
+		{
+			Name:    "max length committed",
+			Options: &textinput.StartOptions{MaxLength: 2},
+			In: textinput.State{
+				Text:      "abc",
+				Committed: true,
+			},
+			Out: textinput.State{
+				Text:      "ab",
+				Committed: true,
+				Phase:     textinput.CompositionPhaseCommitted,
+			},
+			Received: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ch, send, _ := textinput.NewSessionForTesting(c.Options)
+			send(c.In)
+			got, ok := receive(ch)
+			if ok != c.Received {
+				t.Fatalf("received: got: %v, want: %v", ok, c.Received)
+			}
+			if !reflect.DeepEqual(got, c.Out) {
+				t.Errorf("got: %+v, want: %+v", got, c.Out)
+			}
+		})
+	}
+}
+
+func TestSessionPhaseTransition(t *testing.T) {
+	ch, send, cancel := textinput.NewSessionForTesting(nil)
+
+	send(textinput.State{
+		Text:  "k",
+		Phase: textinput.CompositionPhaseComposing,
+	})
+	if got, ok := receive(ch); !ok || got.Phase != textinput.CompositionPhaseComposing || got.Text != "k" {
+		t.Errorf("got: %+v, want: a composing state of %q", got, "k")
+	}
+
+	// Only the last state is kept when the states are not received.
+	send(textinput.State{
+		Text:  "か",
+		Phase: textinput.CompositionPhaseComposing,
+	})
+	send(textinput.State{
+		Text:      "か",
+		Committed: true,
+	})
+	if got, ok := receive(ch); !ok || got.Phase != textinput.CompositionPhaseCommitted || got.Text != "か" {
+		t.Errorf("got: %+v, want: a committed state of %q", got, "か")
+	}
+	if got, ok := receive(ch); ok {
+		t.Errorf("got: %+v, want: no state", got)
+	}
+
+	// A suggested text by text completion is not composing.
+	send(textinput.State{
+		Text: "a",
+	})
+	if got, ok := receive(ch); !ok || got.Phase != textinput.CompositionPhaseNone {
+		t.Errorf("got: %+v, want: a state in the none phase", got)
+	}
+
+	// Canceling sends an empty committed state and closes the channel.
+	send(textinput.State{
+		Text:  "b",
+		Phase: textinput.CompositionPhaseComposing,
+	})
+	cancel()
+	want := textinput.State{
+		Committed: true,
+		Phase:     textinput.CompositionPhaseCommitted,
+	}
+	if got, ok := receive(ch); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+	if _, ok := <-ch; ok {
+		t.Errorf("the channel must be closed after canceling")
+	}
+}
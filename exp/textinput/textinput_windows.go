@@ -35,7 +35,7 @@ type textInput struct {
 
 var theTextInput textInput
 
-func (t *textInput) Start(x, y int) (chan State, func()) {
+func (t *textInput) Start(x, y int, options *StartOptions) (chan State, func()) {
 	if microsoftgdk.IsXbox() {
 		return nil, nil
 	}
@@ -45,7 +45,7 @@ func (t *textInput) Start(x, y int) (chan State, func()) {
 	ui.Get().RunOnMainThread(func() {
 		t.end()
 		err = t.start(x, y)
-		session = newSession()
+		session = newSession(options)
 		t.session = session
 	})
 	if err != nil {
@@ -55,6 +55,25 @@ func (t *textInput) Start(x, y int) (chan State, func()) {
 	return session.ch, session.end
 }
 
+func (t *textInput) Cancel() {
+	ui.Get().RunOnMainThread(func() {
+		if t.session == nil {
+			return
+		}
+
+		// Detach the session first so that messages caused by canceling don't send another state.
+		s := t.session
+		t.session = nil
+
+		// ImmNotifyIME fails when there is no composition. This is not a problem.
+		hIMC := _ImmGetContext(t.window)
+		_ = _ImmNotifyIME(hIMC, _NI_COMPOSITIONSTR, _CPS_CANCEL, 0)
+		_ = _ImmReleaseContext(t.window, hIMC)
+
+		s.cancel()
+	})
+}
+
 // start must be called from the main thread.
 func (t *textInput) start(x, y int) error {
 	if t.window == 0 {
@@ -133,7 +152,9 @@ func (t *textInput) wndProc(hWnd uintptr, uMsg uint32, wParam, lParam uintptr) u
 			t.highSurrogate = 0
 			if c >= 0x20 {
 				str := string(c)
-				t.send(str, 0, len(str), true)
+				t.send(str, 0, len(str), nil, true)
+			} else if c == '\r' && t.session != nil && t.session.multiline {
+				t.send("\n", 0, 1, nil, true)
 			}
 		}
 	case _WM_UNICHAR:
@@ -144,7 +165,7 @@ func (t *textInput) wndProc(hWnd uintptr, uMsg uint32, wParam, lParam uintptr) u
 		}
 		if r := rune(wParam); r >= 0x20 {
 			str := string(r)
-			t.send(str, 0, len(str), true)
+			t.send(str, 0, len(str), nil, true)
 		}
 	}
 
@@ -152,13 +173,20 @@ func (t *textInput) wndProc(hWnd uintptr, uMsg uint32, wParam, lParam uintptr) u
 }
 
 // send must be called from the main thread.
-func (t *textInput) send(text string, startInBytes, endInBytes int, committed bool) {
+func (t *textInput) send(text string, startInBytes, endInBytes int, clauses []Clause, committed bool) {
 	if t.session != nil {
+		// An uncommitted text always comes from the composition string.
+		phase := CompositionPhaseComposing
+		if committed {
+			phase = CompositionPhaseCommitted
+		}
 		t.session.trySend(State{
 			Text:                             text,
 			CompositionSelectionStartInBytes: startInBytes,
 			CompositionSelectionEndInBytes:   endInBytes,
+			Clauses:                          clauses,
 			Committed:                        committed,
+			Phase:                            phase,
 		})
 	}
 	if committed {
@@ -229,7 +257,30 @@ func (t *textInput) update() (ferr error) {
 		}
 	}
 	text := windows.UTF16ToString(buffer16)
-	t.send(text, convertUTF16CountToByteCount(text, start16), convertUTF16CountToByteCount(text, end16), false)
+
+	var clauses []Clause
+	for i := 0; i < len(clause)-1; i++ {
+		c0, c1 := int(clause[i]), int(clause[i+1])
+		if c0 >= len(attr) || c1 > len(buffer16) {
+			break
+		}
+		var style ClauseStyle
+		switch attr[c0] {
+		case _ATTR_TARGET_CONVERTED, _ATTR_TARGET_NOTCONVERTED:
+			style = ClauseStyleTarget
+		case _ATTR_CONVERTED:
+			style = ClauseStyleConverted
+		default:
+			style = ClauseStyleInput
+		}
+		clauses = append(clauses, Clause{
+			StartInBytes: convertUTF16CountToByteCount(text, c0),
+			EndInBytes:   convertUTF16CountToByteCount(text, c1),
+			Style:        style,
+		})
+	}
+
+	t.send(text, convertUTF16CountToByteCount(text, start16), convertUTF16CountToByteCount(text, end16), clauses, false)
 
 	return nil
 }
@@ -257,7 +308,7 @@ func (t *textInput) commit() (ferr error) {
 	}
 
 	text := windows.UTF16ToString(buffer16)
-	t.send(text, 0, len(text), true)
+	t.send(text, 0, len(text), nil, true)
 
 	return nil
 }
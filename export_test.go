@@ -14,6 +14,68 @@
 
 package ebiten
 
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
 var (
 	ImageToBytes = imageToBytes
 )
+
+// KeyPressedWithInputCapturedForTesting returns a function reporting whether a key is pressed in the state,
+// with the given captured state.
+func KeyPressedWithInputCapturedForTesting(state ui.InputState, captured bool) func(key Key) bool {
+	var i inputState
+	i.setCaptured(captured)
+	i.update(func(s *ui.InputState) {
+		*s = state
+	})
+	return i.isKeyPressed
+}
+
+// RecordAndReplayInputStatesForTesting records the input states and returns the replayed input states.
+func RecordAndReplayInputStatesForTesting(states []ui.InputState) ([]ui.InputState, error) {
+	var buf bytes.Buffer
+	recorder := inputRecorder{
+		encoder: gob.NewEncoder(&buf),
+	}
+	for _, s := range states {
+		s := s
+		recorder.update(&s)
+		if recorder.err != nil {
+			return nil, recorder.err
+		}
+	}
+
+	replayer := inputRecorder{
+		decoder: gob.NewDecoder(&buf),
+	}
+	var replayed []ui.InputState
+	for {
+		var s ui.InputState
+		replayer.update(&s)
+		if replayer.err != nil {
+			return nil, replayer.err
+		}
+		if replayer.decoder == nil {
+			break
+		}
+		replayed = append(replayed, s)
+	}
+	return replayed, nil
+}
+
+// DrawOffscreenForTesting calls the game's Draw with offscreen in the same way as the game loop.
+// screen is used as the final screen, and scale, offsetX, and offsetY are the arguments to render the final screen.
+func DrawOffscreenForTesting(game Game, offscreen, screen *Image, scale, offsetX, offsetY float64, transparent bool) error {
+	g := &gameForUI{
+		game:        game,
+		offscreen:   offscreen,
+		screen:      screen,
+		transparent: transparent,
+	}
+	return g.DrawOffscreen(scale, offsetX, offsetY)
+}
@@ -17,6 +17,7 @@ package ebiten
 import (
 	"fmt"
 	"image"
+	"io/fs"
 	"math"
 	"sync/atomic"
 
@@ -38,6 +39,15 @@ type gameForUI struct {
 	screenShader *Shader
 	imageDumper  imageDumper
 	transparent  bool
+
+	// screenScale, screenOffsetX, and screenOffsetY are the arguments for DrawFinalScreen in the current frame.
+	screenScale   float64
+	screenOffsetX float64
+	screenOffsetY float64
+
+	// droppedFiles is the dropped files that are not passed to the drop callback yet.
+	// This keeps the files dropped while Update is paused until Update is resumed.
+	droppedFiles fs.FS
 }
 
 func newGameForUI(game Game, transparent bool) *gameForUI {
@@ -106,9 +116,17 @@ func (g *gameForUI) Layout(outsideWidth, outsideHeight float64) (float64, float6
 
 func (g *gameForUI) UpdateInputState(fn func(*ui.InputState)) {
 	theInputState.update(fn)
+	if fsys := theInputState.droppedFiles(); fsys != nil {
+		g.droppedFiles = fsys
+	}
 }
 
 func (g *gameForUI) Update() error {
+	if err := theInputState.error(); err != nil {
+		return err
+	}
+	runDropCallback(g.droppedFiles)
+	g.droppedFiles = nil
 	if err := g.game.Update(); err != nil {
 		return err
 	}
@@ -118,21 +136,37 @@ func (g *gameForUI) Update() error {
 	return nil
 }
 
-func (g *gameForUI) DrawOffscreen() error {
+func (g *gameForUI) DrawOffscreen(screenScale, screenOffsetX, screenOffsetY float64) error {
+	g.screenScale = screenScale
+	g.screenOffsetX = screenOffsetX
+	g.screenOffsetY = screenOffsetY
+
+	theDrawingGame.Store(g)
 	g.game.Draw(g.offscreen)
+	theDrawingGame.Store(nil)
+	recordFrame(g.offscreen, g.transparent)
 	if err := g.imageDumper.dump(g.offscreen, g.transparent); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (g *gameForUI) DeviceScaleFactorChanged(deviceScaleFactor float64) {
+	runDeviceScaleFactorChangedCallback(deviceScaleFactor)
+}
+
 func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
+	g.drawFinalScreen(g.screen, scale, offsetX, offsetY)
+}
+
+// drawFinalScreen renders the offscreen onto screen, which is the final screen or an image of the same size.
+func (g *gameForUI) drawFinalScreen(screen *Image, scale, offsetX, offsetY float64) {
 	var geoM GeoM
 	geoM.Scale(scale, scale)
 	geoM.Translate(offsetX, offsetY)
 
 	if d, ok := g.game.(FinalScreenDrawer); ok {
-		d.DrawFinalScreen(g.screen, g.offscreen, geoM)
+		d.DrawFinalScreen(screen, g.offscreen, geoM)
 		return
 	}
 
@@ -140,17 +174,17 @@ func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
 	case !screenFilterEnabled.Load(), math.Floor(scale) == scale:
 		op := &DrawImageOptions{}
 		op.GeoM = geoM
-		g.screen.DrawImage(g.offscreen, op)
+		screen.DrawImage(g.offscreen, op)
 	case scale < 1:
 		op := &DrawImageOptions{}
 		op.GeoM = geoM
 		op.Filter = FilterLinear
-		g.screen.DrawImage(g.offscreen, op)
+		screen.DrawImage(g.offscreen, op)
 	default:
 		op := &DrawRectShaderOptions{}
 		op.Images[0] = g.offscreen
 		op.GeoM = geoM
 		w, h := g.offscreen.Bounds().Dx(), g.offscreen.Bounds().Dy()
-		g.screen.DrawRectShader(w, h, g.screenShader, op)
+		screen.DrawRectShader(w, h, g.screenShader, op)
 	}
 }
@@ -198,6 +198,64 @@ func (g *GeoM) Invert() {
 	g.ty = ty
 }
 
+// Decompose decomposes the matrix into a translation, a rotation and a scale.
+//
+// The matrix is equivalent to the one built by Scale(sx, sy), Rotate(rotation) and Translate(tx, ty) in this order.
+// The unit of rotation is radian, and rotation is in [-π, π].
+// If the matrix flips an image, i.e. the determinant is negative, sy is negative.
+//
+// ok is false if the matrix has a shear (e.g. by Skew) or is not invertible.
+// In this case, the returned values ignore the shear and don't reproduce the matrix.
+func (g *GeoM) Decompose() (tx, ty, rotation, sx, sy float64, ok bool) {
+	a, b, c, d := g.a_1+1, g.b, g.c, g.d_1+1
+	tx, ty = g.tx, g.ty
+
+	sx = math.Hypot(a, c)
+	if sx == 0 {
+		return tx, ty, 0, 0, math.Hypot(b, d), false
+	}
+	rotation = math.Atan2(c, a)
+	det := g.det2x2()
+	sy = det / sx
+	if det == 0 {
+		return tx, ty, rotation, sx, sy, false
+	}
+
+	// Without a shear, the two column vectors are orthogonal.
+	const epsilon = 1e-9
+	if math.Abs(a*b+c*d) > epsilon*sx*math.Hypot(b, d) {
+		return tx, ty, rotation, sx, sy, false
+	}
+	return tx, ty, rotation, sx, sy, true
+}
+
+// LerpGeoM returns a matrix interpolating a and b at t.
+//
+// LerpGeoM decomposes a and b by Decompose, interpolates the translations, the rotations and the scales linearly,
+// and composes them again. The rotation is interpolated along the shorter arc.
+// Thus, LerpGeoM is suitable for tweening an object moving, rotating and scaling at the same time.
+//
+// LerpGeoM ignores shears of a and b.
+//
+// t is not clamped. If t is outside [0, 1], the components are extrapolated.
+func LerpGeoM(a, b GeoM, t float64) GeoM {
+	atx, aty, ar, asx, asy, _ := a.Decompose()
+	btx, bty, br, bsx, bsy, _ := b.Decompose()
+
+	lerp := func(x, y float64) float64 {
+		return x + (y-x)*t
+	}
+
+	// Choose the shorter arc. math.Remainder returns a value in [-π, π].
+	dr := math.Remainder(br-ar, 2*math.Pi)
+
+	var g GeoM
+	g.Scale(lerp(asx, bsx), lerp(asy, bsy))
+	g.Rotate(ar + dr*t)
+	g.Translate(lerp(atx, btx), lerp(aty, bty))
+	return g
+}
+
 // SetElement sets an element at (i, j).
 func (g *GeoM) SetElement(i, j int, element float64) {
 	e := element
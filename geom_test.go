@@ -374,3 +374,164 @@ func BenchmarkGeoM(b *testing.B) {
 		m.Rotate(math.Pi / 2)
 	}
 }
+
+func TestGeoMDecompose(t *testing.T) {
+	tests := []struct {
+		name     string
+		tx       float64
+		ty       float64
+		rotation float64
+		sx       float64
+		sy       float64
+	}{
+		{
+			name: "identity",
+			sx:   1,
+			sy:   1,
+		},
+		{
+			name: "translate",
+			tx:   10,
+			ty:   -20,
+			sx:   1,
+			sy:   1,
+		},
+		{
+			name: "scale",
+			sx:   2,
+			sy:   0.5,
+		},
+		{
+			name:     "rotate",
+			rotation: math.Pi / 3,
+			sx:       1,
+			sy:       1,
+		},
+		{
+			name:     "all",
+			tx:       3,
+			ty:       4,
+			rotation: -2,
+			sx:       1.5,
+			sy:       3,
+		},
+		{
+			name:     "flip",
+			tx:       3,
+			ty:       4,
+			rotation: 0.5,
+			sx:       2,
+			sy:       -1,
+		},
+	}
+
+	const delta = 1e-9
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var g ebiten.GeoM
+			g.Scale(test.sx, test.sy)
+			g.Rotate(test.rotation)
+			g.Translate(test.tx, test.ty)
+
+			tx, ty, rotation, sx, sy, ok := g.Decompose()
+			if !ok {
+				t.Fatalf("ok: got: false, want: true")
+			}
+			if math.Abs(tx-test.tx) > delta || math.Abs(ty-test.ty) > delta {
+				t.Errorf("translation: got: (%f, %f), want: (%f, %f)", tx, ty, test.tx, test.ty)
+			}
+			if math.Abs(rotation-test.rotation) > delta {
+				t.Errorf("rotation: got: %f, want: %f", rotation, test.rotation)
+			}
+			if math.Abs(sx-test.sx) > delta || math.Abs(sy-test.sy) > delta {
+				t.Errorf("scale: got: (%f, %f), want: (%f, %f)", sx, sy, test.sx, test.sy)
+			}
+		})
+	}
+}
+
+func TestGeoMDecomposeShear(t *testing.T) {
+	var g ebiten.GeoM
+	g.Scale(2, 3)
+	g.Skew(0.5, 0)
+	if _, _, _, _, _, ok := g.Decompose(); ok {
+		t.Errorf("ok for a skewed matrix: got: true, want: false")
+	}
+
+	g.Reset()
+	g.Scale(0, 1)
+	if _, _, _, _, _, ok := g.Decompose(); ok {
+		t.Errorf("ok for a non-invertible matrix: got: true, want: false")
+	}
+}
+
+func TestLerpGeoM(t *testing.T) {
+	newTRS := func(tx, ty, rotation, sx, sy float64) ebiten.GeoM {
+		var g ebiten.GeoM
+		g.Scale(sx, sy)
+		g.Rotate(rotation)
+		g.Translate(tx, ty)
+		return g
+	}
+
+	tests := []struct {
+		name string
+		a    ebiten.GeoM
+		b    ebiten.GeoM
+		t    float64
+		want ebiten.GeoM
+	}{
+		{
+			name: "start",
+			a:    newTRS(0, 0, 0, 1, 1),
+			b:    newTRS(10, 20, 1, 2, 3),
+			t:    0,
+			want: newTRS(0, 0, 0, 1, 1),
+		},
+		{
+			name: "end",
+			a:    newTRS(0, 0, 0, 1, 1),
+			b:    newTRS(10, 20, 1, 2, 3),
+			t:    1,
+			want: newTRS(10, 20, 1, 2, 3),
+		},
+		{
+			name: "middle",
+			a:    newTRS(0, 0, 0, 1, 1),
+			b:    newTRS(10, 20, 1, 2, 3),
+			t:    0.5,
+			want: newTRS(5, 10, 0.5, 1.5, 2),
+		},
+		{
+			name: "shorter arc",
+			a:    newTRS(0, 0, 3*math.Pi/4, 1, 1),
+			b:    newTRS(0, 0, -3*math.Pi/4, 1, 1),
+			t:    0.5,
+			want: newTRS(0, 0, math.Pi, 1, 1),
+		},
+		{
+			name: "extrapolation",
+			a:    newTRS(0, 0, 0, 1, 1),
+			b:    newTRS(10, 0, 0, 1, 1),
+			t:    2,
+			want: newTRS(20, 0, 0, 1, 1),
+		},
+	}
+
+	const delta = 1e-9
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got := ebiten.LerpGeoM(test.a, test.b, test.t)
+			for i := 0; i < ebiten.GeoMDim-1; i++ {
+				for j := 0; j < ebiten.GeoMDim; j++ {
+					if math.Abs(got.Element(i, j)-test.want.Element(i, j)) > delta {
+						t.Errorf("got: %s, want: %s", got.String(), test.want.String())
+						return
+					}
+				}
+			}
+		})
+	}
+}
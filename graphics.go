@@ -28,6 +28,12 @@ const (
 
 	// FilterLinear represents linear filter
 	FilterLinear Filter = Filter(builtinshader.FilterLinear)
+
+	// FilterBicubic represents bicubic (Catmull-Rom) filter.
+	// FilterBicubic is sharper than FilterLinear for magnification, but more expensive.
+	//
+	// In DrawImage and colorm.DrawImage, FilterBicubic is used only for magnification, and FilterLinear is used for minification instead.
+	FilterBicubic Filter = Filter(builtinshader.FilterBicubic)
 )
 
 // GraphicsLibrary represents graphics libraries supported by the engine.
@@ -40,6 +40,9 @@ type Image struct {
 	original *Image
 	bounds   image.Rectangle
 
+	// mipmapDisabled is valid only for an original image. Use isMipmapDisabled instead.
+	mipmapDisabled bool
+
 	// tmpVertices must not be reused until ui.Image.Draw* is called.
 	tmpVertices []float32
 
@@ -50,6 +53,13 @@ type Image struct {
 	// This tends to forget resolving the buffer easily (#2362).
 }
 
+func (i *Image) isMipmapDisabled() bool {
+	if i.isSubImage() {
+		return i.original.mipmapDisabled
+	}
+	return i.mipmapDisabled
+}
+
 func (i *Image) copyCheck() {
 	if i.addr != i {
 		panic("ebiten: illegal use of non-zero Image copied by value")
@@ -104,6 +114,15 @@ func canSkipMipmap(geom GeoM, filter builtinshader.Filter) bool {
 	return geom.det2x2() >= 0.999
 }
 
+// isMinification reports whether geom shrinks an image along any of the axes.
+//
+// The determinant is not enough for this purpose, as an anisotropic scale like (4, 0.5) has the determinant 2.
+func isMinification(geom GeoM) bool {
+	a, b, c, d := geom.Element(0, 0), geom.Element(0, 1), geom.Element(1, 0), geom.Element(1, 1)
+	// Check the lengths of the images of the unit vectors.
+	return a*a+c*c < 1 || b*b+d*d < 1
+}
+
 // DrawImageOptions represents options for DrawImage.
 type DrawImageOptions struct {
 	// GeoM is a geometry matrix to draw.
@@ -231,6 +250,10 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
 	filter := builtinshader.Filter(options.Filter)
+	// The bicubic filter doesn't improve minification, and mipmaps work only with the linear filter.
+	if filter == builtinshader.FilterBicubic && isMinification(options.GeoM) {
+		filter = builtinshader.FilterLinear
+	}
 
 	geoM := options.GeoM
 	if offsetX, offsetY := i.adjustPosition(0, 0); offsetX != 0 || offsetY != 0 {
@@ -262,7 +285,7 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 		})
 	}
 
-	i.image.DrawTriangles(srcs, vs, is, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, canSkipMipmap(geoM, filter), false)
+	i.image.DrawTriangles(srcs, vs, is, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillAll, canSkipMipmap(geoM, filter) || img.isMipmapDisabled(), false)
 }
 
 // Vertex represents a vertex passed to DrawTriangles.
@@ -305,6 +328,10 @@ const (
 
 	// AddressRepeat means that texture coordinates wrap to the other side of the texture.
 	AddressRepeat Address = Address(builtinshader.AddressRepeat)
+
+	// AddressMirrorRepeat means that texture coordinates wrap to the other side of the texture with mirroring.
+	// The texture is flipped at every repetition, so the edges of adjacent tiles are seamless.
+	AddressMirrorRepeat Address = Address(builtinshader.AddressMirrorRepeat)
 )
 
 // FillRule is the rule whether an overlapped region is rendered with DrawTriangles(Shader).
@@ -428,6 +455,18 @@ const MaxVertexCount = graphicscommand.MaxVertexCount
 //
 // When the image i is disposed, DrawTriangles does nothing.
 func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, options *DrawTrianglesOptions) {
+	is := make([]uint32, len(indices))
+	for i := range is {
+		is[i] = uint32(indices[i])
+	}
+	i.DrawTriangles32(vertices, is, img, options)
+}
+
+// DrawTriangles32 draws triangles with the specified vertices and their 32-bit indices.
+//
+// DrawTriangles32 is the same as DrawTriangles except for the type of indices.
+// With 32-bit indices, a mesh with more than 65536 vertices can be drawn at once without splitting it.
+func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image, options *DrawTrianglesOptions) {
 	i.copyCheck()
 
 	if img != nil && img.isDisposed() {
@@ -495,11 +534,6 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 			vs[i*graphics.VertexFloatCount+7] = v.ColorA * ca
 		}
 	}
-	is := make([]uint32, len(indices))
-	for i := range is {
-		is[i] = uint32(indices[i])
-	}
-
 	srcs := [graphics.ShaderImageCount]*ui.Image{img.image}
 
 	useColorM := !colorm.IsIdentity()
@@ -515,7 +549,7 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 		})
 	}
 
-	i.image.DrawTriangles(srcs, vs, is, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), filter != builtinshader.FilterLinear, options.AntiAlias)
+	i.image.DrawTriangles(srcs, vs, indices, blend, i.adjustedBounds(), [graphics.ShaderImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), filter != builtinshader.FilterLinear || img.isMipmapDisabled(), options.AntiAlias)
 }
 
 // DrawTrianglesShaderOptions represents options for DrawTrianglesShader.
@@ -592,6 +626,18 @@ var _ [len(DrawTrianglesShaderOptions{}.Images) - graphics.ShaderImageCount]stru
 //
 // When the image i is disposed, DrawTrianglesShader does nothing.
 func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader *Shader, options *DrawTrianglesShaderOptions) {
+	is := make([]uint32, len(indices))
+	for i := range is {
+		is[i] = uint32(indices[i])
+	}
+	i.DrawTrianglesShader32(vertices, is, shader, options)
+}
+
+// DrawTrianglesShader32 draws triangles with the specified vertices and their 32-bit indices with the specified shader.
+//
+// DrawTrianglesShader32 is the same as DrawTrianglesShader except for the type of indices.
+// With 32-bit indices, a mesh with more than 65536 vertices can be drawn at once without splitting it.
+func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shader *Shader, options *DrawTrianglesShaderOptions) {
 	i.copyCheck()
 
 	if i.isDisposed() {
@@ -645,11 +691,6 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 		vs[i*graphics.VertexFloatCount+7] = v.ColorA
 	}
 
-	is := make([]uint32, len(indices))
-	for i := range is {
-		is[i] = uint32(indices[i])
-	}
-
 	var imgs [graphics.ShaderImageCount]*ui.Image
 	var imgSize image.Point
 	for i, img := range options.Images {
@@ -683,7 +724,7 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 	i.tmpUniforms = i.tmpUniforms[:0]
 	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, options.Uniforms)
 
-	i.image.DrawTriangles(imgs, vs, is, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, options.AntiAlias)
+	i.image.DrawTriangles(imgs, vs, indices, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, options.AntiAlias)
 }
 
 // DrawRectShaderOptions represents options for DrawRectShader.
@@ -1083,6 +1124,14 @@ type NewImageOptions struct {
 	// A regular image is a part of an internal texture atlas, and locating them is done automatically in Ebitengine.
 	// Unmanaged is useful when you want finer controls over the image for performance and memory reasons.
 	Unmanaged bool
+
+	// DisableMipmaps represents whether mipmaps are never used when the image is drawn as a source.
+	// The default (zero) value is false, that means mipmaps are generated and used for minified draws with FilterLinear.
+	//
+	// Mipmaps are generated lazily only when the image is minified, and are regenerated automatically after the image is modified.
+	// DisableMipmaps is useful to save memory for an image that is minified but doesn't need smooth results.
+	// When DisableMipmaps is true, a minified draw samples the original image, which might cause aliasing.
+	DisableMipmaps bool
 }
 
 // NewImageWithOptions returns an empty image with the given bounds and the options.
@@ -1105,7 +1154,11 @@ func NewImageWithOptions(bounds image.Rectangle, options *NewImageOptions) *Imag
 	if options != nil && options.Unmanaged {
 		imageType = atlas.ImageTypeUnmanaged
 	}
-	return newImage(bounds, imageType)
+	i := newImage(bounds, imageType)
+	if options != nil {
+		i.mipmapDisabled = options.DisableMipmaps
+	}
+	return i
 }
 
 func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
@@ -1140,6 +1193,10 @@ func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
 // NewImageFromImage panics if RunGame already finishes.
 //
 // The returned image's upper-left position is always (0, 0). The source's bounds are not respected.
+// To preserve the bounds, use NewImageFromImageWithOptions with PreserveBounds.
+//
+// The source's colors are read as alpha-premultiplied values as color.Color's RGBA returns.
+// For example, the pixels of *image.NRGBA are premultiplied in the returned image.
 func NewImageFromImage(source image.Image) *Image {
 	return NewImageFromImageWithOptions(source, nil)
 }
@@ -1157,6 +1214,10 @@ type NewImageFromImageOptions struct {
 	// PreserveBounds represents whether the new image's bounds are the same as the given image.
 	// The default (zero) value is false, that means the new image's upper-left position is adjusted to (0, 0).
 	PreserveBounds bool
+
+	// DisableMipmaps represents whether mipmaps are never used when the image is drawn as a source.
+	// See NewImageOptions.DisableMipmaps.
+	DisableMipmaps bool
 }
 
 // NewImageFromImageWithOptions creates a new image with the given image (source) with the given options.
@@ -1170,6 +1231,8 @@ type NewImageFromImageOptions struct {
 // Reusing the same image by Clear and WritePixels is much more efficient than creating a new image.
 //
 // NewImageFromImageWithOptions panics if RunGame already finishes.
+//
+// The source's colors are read as alpha-premultiplied values as color.Color's RGBA returns.
 func NewImageFromImageWithOptions(source image.Image, options *NewImageFromImageOptions) *Image {
 	if options == nil {
 		options = &NewImageFromImageOptions{}
@@ -1183,7 +1246,8 @@ func NewImageFromImageWithOptions(source image.Image, options *NewImageFromImage
 		r = image.Rect(0, 0, size.X, size.Y)
 	}
 	i := NewImageWithOptions(r, &NewImageOptions{
-		Unmanaged: options.Unmanaged,
+		Unmanaged:      options.Unmanaged,
+		DisableMipmaps: options.DisableMipmaps,
 	})
 
 	// If the given image is an Ebitengine image, use DrawImage instead of reading pixels from the source.
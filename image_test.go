@@ -20,9 +20,11 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
-	_ "image/png"
+	"image/png"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
@@ -30,6 +32,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/examples/resources/images"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
 	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
@@ -348,6 +351,93 @@ func TestImageDeallocate(t *testing.T) {
 	}
 }
 
+func TestGPUMemoryUsageAfterDeallocate(t *testing.T) {
+	img := ebiten.NewImageWithOptions(image.Rect(0, 0, 16, 16), &ebiten.NewImageOptions{
+		Unmanaged: true,
+	})
+	img.Fill(color.White)
+	// Read the pixels to ensure the image is allocated on GPU.
+	_ = img.At(0, 0)
+
+	before := ebiten.GPUMemoryUsage()
+	img.Deallocate()
+
+	// GPUMemoryUsage must not panic after an unmanaged image is deallocated.
+	after := ebiten.GPUMemoryUsage()
+	if after > before {
+		t.Errorf("ebiten.GPUMemoryUsage() after Deallocate: got: %d, want: <= %d", after, before)
+	}
+}
+
+func TestDrawCallCount(t *testing.T) {
+	// Use unmanaged images so that the sources are not on the same atlas.
+	newImage := func(width, height int) *ebiten.Image {
+		return ebiten.NewImageWithOptions(image.Rect(0, 0, width, height), &ebiten.NewImageOptions{
+			Unmanaged: true,
+		})
+	}
+	src0 := newImage(4, 4)
+	src0.Fill(color.White)
+	src1 := newImage(4, 4)
+	src1.Fill(color.Black)
+	dst := newImage(16, 16)
+
+	// countDrawCalls returns the number of the draw calls that draw issues.
+	countDrawCalls := func(draw func()) int {
+		// Read the pixels to flush the commands.
+		_ = dst.At(0, 0)
+		before := graphicscommand.CurrentFrameDrawCallCountForTesting()
+		draw()
+		_ = dst.At(0, 0)
+		return graphicscommand.CurrentFrameDrawCallCountForTesting() - before
+	}
+
+	cases := []struct {
+		Name   string
+		Srcs   []*ebiten.Image
+		Blends []ebiten.Blend
+		Want   int
+	}{
+		{
+			Name:   "same source and blend",
+			Srcs:   []*ebiten.Image{src0, src0, src0},
+			Blends: []ebiten.Blend{ebiten.BlendSourceOver, ebiten.BlendSourceOver, ebiten.BlendSourceOver},
+			Want:   1,
+		},
+		{
+			Name:   "different sources",
+			Srcs:   []*ebiten.Image{src0, src1, src0},
+			Blends: []ebiten.Blend{ebiten.BlendSourceOver, ebiten.BlendSourceOver, ebiten.BlendSourceOver},
+			Want:   3,
+		},
+		{
+			Name:   "different blends",
+			Srcs:   []*ebiten.Image{src0, src0, src0},
+			Blends: []ebiten.Blend{ebiten.BlendSourceOver, ebiten.BlendCopy, ebiten.BlendSourceOver},
+			Want:   3,
+		},
+		{
+			Name:   "grouped sources",
+			Srcs:   []*ebiten.Image{src0, src0, src1, src1},
+			Blends: []ebiten.Blend{ebiten.BlendSourceOver, ebiten.BlendSourceOver, ebiten.BlendSourceOver, ebiten.BlendSourceOver},
+			Want:   2,
+		},
+	}
+	for _, c := range cases {
+		got := countDrawCalls(func() {
+			for i, src := range c.Srcs {
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(float64(4*i), 0)
+				op.Blend = c.Blends[i]
+				dst.DrawImage(src, op)
+			}
+		})
+		if got != c.Want {
+			t.Errorf("%s: got: %d, want: %d", c.Name, got, c.Want)
+		}
+	}
+}
+
 type ordered interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64 | ~string
 }
@@ -1439,6 +1529,95 @@ func TestImageAddressRepeatNegativePosition(t *testing.T) {
 	}
 }
 
+func TestImageAddressMirrorRepeat(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	dst := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			if 4 <= i && i < 8 && 4 <= j && j < 8 {
+				pix[idx] = byte(i-4) * 0x10
+				pix[idx+1] = byte(j-4) * 0x10
+				pix[idx+2] = 0
+				pix[idx+3] = 0xff
+			} else {
+				pix[idx] = 0
+				pix[idx+1] = 0
+				pix[idx+2] = 0xff
+				pix[idx+3] = 0xff
+			}
+		}
+	}
+	src.WritePixels(pix)
+
+	vs := []ebiten.Vertex{
+		{
+			DstX:   0,
+			DstY:   0,
+			SrcX:   0,
+			SrcY:   0,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		},
+		{
+			DstX:   w,
+			DstY:   0,
+			SrcX:   w,
+			SrcY:   0,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		},
+		{
+			DstX:   0,
+			DstY:   h,
+			SrcX:   0,
+			SrcY:   h,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		},
+		{
+			DstX:   w,
+			DstY:   h,
+			SrcX:   w,
+			SrcY:   h,
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	op := &ebiten.DrawTrianglesOptions{}
+	op.Address = ebiten.AddressMirrorRepeat
+	dst.DrawTriangles(vs, is, src.SubImage(image.Rect(4, 4, 8, 8)).(*ebiten.Image), op)
+
+	mirror := func(x int) byte {
+		// The sub-image starts at (4, 4). The texture is flipped every 4 pixels.
+		x = (x - 4 + 8) % 8
+		if x >= 4 {
+			x = 7 - x
+		}
+		return byte(x)
+	}
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{R: mirror(i) * 0x10, G: mirror(j) * 0x10, A: 0xff}
+			if !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
 func TestImageWritePixelsAfterClear(t *testing.T) {
 	const w, h = 256, 256
 	img := ebiten.NewImage(w, h)
@@ -1813,6 +1992,78 @@ func TestImageTooSmallMipmap(t *testing.T) {
 	}
 }
 
+func TestImageDisableMipmaps(t *testing.T) {
+	const w, h = 20, 20
+	src := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		DisableMipmaps: true,
+	})
+	dst := ebiten.NewImage(w/5, h/5)
+
+	// Fill the source with vertical stripes.
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			if i%2 == 0 {
+				pix[idx] = 0xff
+				pix[idx+1] = 0xff
+				pix[idx+2] = 0xff
+			}
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix)
+
+	// Each destination pixel's center corresponds to a source texel's center at an even column.
+	// Without mipmaps, the stripes are not averaged.
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(0.2, 0.2)
+	op.Filter = ebiten.FilterLinear
+	dst.DrawImage(src, op)
+	got := dst.At(0, 0).(color.RGBA)
+	want := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	if !sameColors(got, want, 1) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func BenchmarkImageDisableMipmapsMemory(b *testing.B) {
+	const w, h = 512, 512
+
+	for _, disabled := range []bool{false, true} {
+		b.Run(fmt.Sprintf("disabled=%t", disabled), func(b *testing.B) {
+			dst := ebiten.NewImage(w/8, h/8)
+			defer dst.Deallocate()
+
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Scale(1.0/8, 1.0/8)
+			op.Filter = ebiten.FilterLinear
+
+			var usage int
+			for i := 0; i < b.N; i++ {
+				// Use an unmanaged image so that the source and its mipmaps are isolated from an atlas,
+				// and the texture usage grows exactly by the mipmaps.
+				src := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+					Unmanaged:      true,
+					DisableMipmaps: disabled,
+				})
+				src.Fill(color.White)
+				// Read the pixels to ensure the images are allocated on GPU.
+				_ = src.At(0, 0)
+				_ = dst.At(0, 0)
+
+				before := ebiten.GPUMemoryUsage()
+				dst.DrawImage(src, op)
+				_ = dst.At(0, 0)
+				usage += ebiten.GPUMemoryUsage() - before
+
+				src.Deallocate()
+			}
+			b.ReportMetric(float64(usage)/float64(b.N), "gpu-bytes/op")
+		})
+	}
+}
+
 func TestImageZeroSizedMipmap(t *testing.T) {
 	const w, h = 16, 16
 	src := ebiten.NewImage(w, h)
@@ -4623,3 +4874,451 @@ func TestImageInvalidPremultipliedAlphaColor(t *testing.T) {
 		}
 	}
 }
+
+func TestImageBlendPresets(t *testing.T) {
+	dstColor := color.RGBA{R: 0x40, G: 0x80, B: 0xc0, A: 0xff}
+	srcColor := color.RGBA{R: 0x60, G: 0x20, B: 0x80, A: 0x80}
+
+	mul := func(x, y byte) int {
+		return int(math.Round(float64(x) * float64(y) / 0xff))
+	}
+	sourceOverAlpha := byte(int(srcColor.A) + mul(dstColor.A, 0xff-srcColor.A))
+
+	testCases := []struct {
+		Name  string
+		Blend ebiten.Blend
+		Want  color.RGBA
+	}{
+		{
+			Name:  "multiply",
+			Blend: ebiten.BlendMultiply,
+			Want: color.RGBA{
+				R: byte(mul(srcColor.R, dstColor.R) + mul(dstColor.R, 0xff-srcColor.A)),
+				G: byte(mul(srcColor.G, dstColor.G) + mul(dstColor.G, 0xff-srcColor.A)),
+				B: byte(mul(srcColor.B, dstColor.B) + mul(dstColor.B, 0xff-srcColor.A)),
+				A: sourceOverAlpha,
+			},
+		},
+		{
+			Name:  "screen",
+			Blend: ebiten.BlendScreen,
+			Want: color.RGBA{
+				R: byte(int(srcColor.R) + mul(dstColor.R, 0xff-srcColor.R)),
+				G: byte(int(srcColor.G) + mul(dstColor.G, 0xff-srcColor.G)),
+				B: byte(int(srcColor.B) + mul(dstColor.B, 0xff-srcColor.B)),
+				A: sourceOverAlpha,
+			},
+		},
+		{
+			Name:  "darken",
+			Blend: ebiten.BlendDarken,
+			Want: color.RGBA{
+				R: min(srcColor.R, dstColor.R),
+				G: min(srcColor.G, dstColor.G),
+				B: min(srcColor.B, dstColor.B),
+				A: sourceOverAlpha,
+			},
+		},
+		{
+			Name:  "lighten",
+			Blend: ebiten.BlendLighten,
+			Want: color.RGBA{
+				R: max(srcColor.R, dstColor.R),
+				G: max(srcColor.G, dstColor.G),
+				B: max(srcColor.B, dstColor.B),
+				A: sourceOverAlpha,
+			},
+		},
+	}
+
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(w, h)
+	src.Fill(srcColor)
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := tc.Blend.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			dst.Fill(dstColor)
+			op := &ebiten.DrawImageOptions{}
+			op.Blend = tc.Blend
+			dst.DrawImage(src, op)
+			got := dst.At(0, 0).(color.RGBA)
+			if !sameColors(got, tc.Want, 1) {
+				t.Errorf("got: %v, want: %v", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestBlendValidate(t *testing.T) {
+	if err := (ebiten.Blend{}).Validate(); err != nil {
+		t.Errorf("Validate for the default Blend: got: %v, want: nil", err)
+	}
+	if err := (ebiten.Blend{BlendFactorSourceRGB: 0xff}).Validate(); err == nil {
+		t.Errorf("Validate for an invalid factor: got: nil, want: an error")
+	}
+	if err := (ebiten.Blend{BlendOperationAlpha: 0xff}).Validate(); err == nil {
+		t.Errorf("Validate for an invalid operation: got: nil, want: an error")
+	}
+}
+
+func TestImageFilterBicubic(t *testing.T) {
+	const (
+		w     = 4
+		h     = 4
+		scale = 4
+	)
+
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			pix[idx] = byte((i*5 + j*3) % 7 * 0x24)
+			pix[idx+1] = byte((i*2 + j*5) % 5 * 0x33)
+			pix[idx+2] = byte(i * 0x55)
+			pix[idx+3] = 0xff
+		}
+	}
+	src := ebiten.NewImage(w, h)
+	src.WritePixels(pix)
+
+	dst := ebiten.NewImage(w*scale, h*scale)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.Filter = ebiten.FilterBicubic
+	dst.DrawImage(src, op)
+
+	// Calculate the reference image on the CPU side.
+	// The texels outside the source image are clamped to the edge.
+	weights := func(t float64) [4]float64 {
+		return [4]float64{
+			t * (-0.5 + t*(1-0.5*t)),
+			1 + t*t*(-2.5+1.5*t),
+			t * (0.5 + t*(2-1.5*t)),
+			t * t * (-0.5 + 0.5*t),
+		}
+	}
+	clampInt := func(x, min, max int) int {
+		if x < min {
+			return min
+		}
+		if x > max {
+			return max
+		}
+		return x
+	}
+	for j := 0; j < h*scale; j++ {
+		for i := 0; i < w*scale; i++ {
+			px := (float64(i)+0.5)/scale - 0.5
+			py := (float64(j)+0.5)/scale - 0.5
+			bx, by := math.Floor(px), math.Floor(py)
+			wx, wy := weights(px-bx), weights(py-by)
+
+			var want color.RGBA
+			for c := 0; c < 4; c++ {
+				var v float64
+				for y := 0; y < 4; y++ {
+					for x := 0; x < 4; x++ {
+						sx := clampInt(int(bx)-1+x, 0, w-1)
+						sy := clampInt(int(by)-1+y, 0, h-1)
+						v += wx[x] * wy[y] * float64(pix[4*(sx+sy*w)+c])
+					}
+				}
+				v = math.Round(math.Max(0, math.Min(0xff, v)))
+				switch c {
+				case 0:
+					want.R = byte(v)
+				case 1:
+					want.G = byte(v)
+				case 2:
+					want.B = byte(v)
+				case 3:
+					want.A = byte(v)
+				}
+			}
+
+			got := dst.At(i, j).(color.RGBA)
+			if !sameColors(got, want, 2) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageDrawTriangles32(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+
+	// Put the vertices beyond the range of uint16.
+	const offset = 1 << 16
+	vs := make([]ebiten.Vertex, offset+4)
+	for i, p := range [][2]float32{{0, 0}, {w, 0}, {0, h}, {w, h}} {
+		vs[offset+i] = ebiten.Vertex{
+			DstX:   p[0],
+			DstY:   p[1],
+			SrcX:   p[0],
+			SrcY:   p[1],
+			ColorR: 1,
+			ColorG: 0,
+			ColorB: 0,
+			ColorA: 1,
+		}
+	}
+	is := []uint32{offset, offset + 1, offset + 2, offset + 1, offset + 2, offset + 3}
+	dst.DrawTriangles32(vs, is, src, nil)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0xff, A: 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkDrawTrianglesLargeMesh(b *testing.B) {
+	// A grid mesh with about 200k vertices.
+	const n = 448
+	dst := ebiten.NewImage(n, n)
+	src := ebiten.NewImage(1, 1)
+	src.Fill(color.White)
+
+	vs := make([]ebiten.Vertex, 0, n*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			vs = append(vs, ebiten.Vertex{
+				DstX:   float32(i),
+				DstY:   float32(j),
+				ColorR: 1,
+				ColorG: 1,
+				ColorB: 1,
+				ColorA: 1,
+			})
+		}
+	}
+	var is []uint32
+	for j := 0; j < n-1; j++ {
+		for i := 0; i < n-1; i++ {
+			idx := uint32(i + j*n)
+			is = append(is, idx, idx+1, idx+n, idx+1, idx+n, idx+n+1)
+		}
+	}
+
+	b.Run("uint16", func(b *testing.B) {
+		// Split the mesh by rows so that each part fits in uint16 indices.
+		const rows = (1 << 16) / n
+		var parts [][]ebiten.Vertex
+		var partIndices [][]uint16
+		for j0 := 0; j0 < n-1; j0 += rows - 1 {
+			j1 := j0 + rows
+			if j1 > n {
+				j1 = n
+			}
+			parts = append(parts, vs[j0*n:j1*n])
+			var pis []uint16
+			for j := 0; j < j1-j0-1; j++ {
+				for i := 0; i < n-1; i++ {
+					idx := uint16(i + j*n)
+					pis = append(pis, idx, idx+1, idx+n, idx+1, idx+n, idx+n+1)
+				}
+			}
+			partIndices = append(partIndices, pis)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for k := range parts {
+				dst.DrawTriangles(parts[k], partIndices[k], src, nil)
+			}
+		}
+	})
+	b.Run("uint32", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dst.DrawTriangles32(vs, is, src, nil)
+		}
+	})
+}
+
+func TestImageFilterBicubicAnisotropicMinification(t *testing.T) {
+	const (
+		w = 8
+		h = 8
+	)
+
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			pix[idx] = byte((i*5 + j*3) % 7 * 0x24)
+			pix[idx+1] = byte((i*2 + j*5) % 5 * 0x33)
+			pix[idx+2] = byte(i * 0x20)
+			pix[idx+3] = 0xff
+		}
+	}
+	src := ebiten.NewImage(w, h)
+	src.WritePixels(pix)
+
+	// The determinant is 2, but the image is shrunk vertically.
+	// The linear filter must be used instead of the bicubic filter.
+	dst0 := ebiten.NewImage(w*4, h/2)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(4, 0.5)
+	op.Filter = ebiten.FilterBicubic
+	dst0.DrawImage(src, op)
+
+	dst1 := ebiten.NewImage(w*4, h/2)
+	op.Filter = ebiten.FilterLinear
+	dst1.DrawImage(src, op)
+
+	for j := 0; j < h/2; j++ {
+		for i := 0; i < w*4; i++ {
+			got := dst0.At(i, j)
+			want := dst1.At(i, j)
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+type captureScreenGame struct {
+	draw func(screen *ebiten.Image)
+}
+
+func (g *captureScreenGame) Update() error {
+	return nil
+}
+
+func (g *captureScreenGame) Draw(screen *ebiten.Image) {
+	g.draw(screen)
+}
+
+func (g *captureScreenGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+type captureScreenGameWithFinalScreen struct {
+	captureScreenGame
+}
+
+func (g *captureScreenGameWithFinalScreen) DrawFinalScreen(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM) {
+	screen.Fill(color.RGBA{B: 0xff, A: 0xff})
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM = geoM
+	screen.DrawImage(offscreen, op)
+}
+
+func TestImageCaptureScreen(t *testing.T) {
+	const (
+		w = 16
+		h = 16
+
+		// The offscreen is scaled by 2 and is put at the center of the final screen vertically.
+		scale   = 2
+		offsetY = 2
+		sw      = w * scale
+		sh      = h*scale + 2*offsetY
+	)
+
+	if _, err := ebiten.CaptureScreen(); err == nil {
+		t.Errorf("CaptureScreen outside of Draw must return an error")
+	}
+
+	testCases := []struct {
+		name              string
+		finalScreenDrawer bool
+		transparent       bool
+	}{
+		{name: "opaque"},
+		{name: "transparent", transparent: true},
+		{name: "FinalScreenDrawer", finalScreenDrawer: true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "screenshot.png")
+
+			var captured *image.RGBA
+			var captureErr, captureToFileErr error
+			var g ebiten.Game
+			base := captureScreenGame{
+				draw: func(screen *ebiten.Image) {
+					screen.Fill(color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x80})
+					screen.SubImage(image.Rect(0, 0, w/2, h/2)).(*ebiten.Image).Fill(color.RGBA{R: 0xff, A: 0xff})
+					captured, captureErr = ebiten.CaptureScreen()
+					captureToFileErr = ebiten.CaptureScreenToFile(path)
+				},
+			}
+			if tc.finalScreenDrawer {
+				g = &captureScreenGameWithFinalScreen{captureScreenGame: base}
+			} else {
+				g = &base
+			}
+			offscreen := ebiten.NewImage(w, h)
+			screen := ebiten.NewImage(sw, sh)
+			if err := ebiten.DrawOffscreenForTesting(g, offscreen, screen, scale, 0, offsetY, tc.transparent); err != nil {
+				t.Fatal(err)
+			}
+			if captureErr != nil {
+				t.Fatal(captureErr)
+			}
+			if captureToFileErr != nil {
+				t.Fatal(captureToFileErr)
+			}
+
+			if got, want := captured.Bounds(), image.Rect(0, 0, sw, sh); got != want {
+				t.Errorf("bounds: got: %v, want: %v", got, want)
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			saved, err := png.Decode(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for j := 0; j < sh; j++ {
+				for i := 0; i < sw; i++ {
+					var want color.RGBA
+					switch x, y := i/scale, (j-offsetY)/scale; {
+					case j < offsetY || j >= offsetY+h*scale:
+						// The outside of the offscreen.
+					case x < w/2 && y < h/2:
+						want = color.RGBA{R: 0xff, A: 0xff}
+					default:
+						want = color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x80}
+					}
+					if tc.finalScreenDrawer {
+						// The offscreen is drawn on the blue background.
+						a := int(want.A)
+						want.B = byte(int(want.B) + 0xff*(0xff-a)/0xff)
+						want.A = 0xff
+					}
+					if got := captured.At(i, j).(color.RGBA); !sameColors(got, want, 1) {
+						t.Errorf("CaptureScreen: At(%d, %d): got: %v, want: %v", i, j, got, want)
+					}
+
+					// An opaque screen is saved as if it is drawn on a black background.
+					if !tc.transparent {
+						want.A = 0xff
+					}
+					// A translucent color is saved with non-premultiplied alpha, so allow a rounding error.
+					if got := color.RGBAModel.Convert(saved.At(i, j)).(color.RGBA); !sameColors(got, want, 1) {
+						t.Errorf("CaptureScreenToFile: At(%d, %d): got: %v, want: %v", i, j, got, want)
+					}
+				}
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package ebiten
 
 import (
+	"encoding/gob"
+	"io"
 	"io/fs"
 	"sync"
 
@@ -61,13 +63,47 @@ func IsKeyPressed(key Key) bool {
 	return theInputState.isKeyPressed(key)
 }
 
+// SetInputCaptured sets whether the keyboard input is captured for text inputting, e.g. while a chat box is focused.
+//
+// While the input is captured, the character keys, which input letters, digits, symbols, and a space, are regarded
+// as not pressed. For example, IsKeyPressed(KeyW) returns false even when the W key is pressed.
+// This prevents the game from reacting to the keys for the text, like moving a character by WASD keys.
+// The other keys like KeyEscape, KeyEnter, KeyTab, KeyBackspace, the arrow keys, and the modifier keys are not affected,
+// so that the game can handle the keys to finish or edit the text.
+//
+// The input characters are not affected. AppendInputChars and the exp/textinput package still receive the text.
+// On browsers, the key events from the text input element of the exp/textinput package are also suppressed in the same way.
+// The input recorded by RecordInput doesn't depend on the captured state.
+//
+// The captured state is applied from the next tick.
+//
+// SetInputCaptured is concurrent-safe.
+func SetInputCaptured(captured bool) {
+	theInputState.setCaptured(captured)
+}
+
+// IsInputCaptured reports whether the keyboard input is captured by SetInputCaptured.
+//
+// IsInputCaptured is concurrent-safe.
+func IsInputCaptured() bool {
+	return theInputState.isCaptured()
+}
+
 // KeyName returns a key name for the current keyboard layout.
 // For example, KeyName(KeyQ) returns 'q' for a QWERTY keyboard, and returns 'a' for an AZERTY keyboard.
 //
+// While a Key represents a physical key position, KeyName returns the character that the key inputs
+// without modifier keys in the current layout. A letter is in the lower case.
+// Use a Key for key bindings, and use KeyName to show the key to users.
+//
 // KeyName returns an empty string if 1) the key doesn't have a physical key name, 2) the platform doesn't support KeyName,
 // or 3) the main loop doesn't start yet.
 //
 // KeyName is supported by desktops and browsers.
+// On desktops, a key name is the key symbol for the scan code of the key.
+// On browsers, a Key is from KeyboardEvent.code, and a key name is from Keyboard.getLayoutMap.
+// If Keyboard.getLayoutMap is not available, a key name is learned from KeyboardEvent.key when the key is pressed,
+// and KeyName returns an empty string until then.
 //
 // KeyName is concurrent-safe.
 func KeyName(key Key) string {
@@ -115,7 +151,7 @@ type GamepadID = gamepad.ID
 //
 // GamepadSDLID is concurrent-safe.
 func GamepadSDLID(id GamepadID) string {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return ""
 	}
@@ -132,7 +168,7 @@ func GamepadSDLID(id GamepadID) string {
 //
 // GamepadName is concurrent-safe.
 func GamepadName(id GamepadID) string {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return ""
 	}
@@ -144,7 +180,7 @@ func GamepadName(id GamepadID) string {
 //
 // AppendGamepadIDs is concurrent-safe.
 func AppendGamepadIDs(gamepadIDs []GamepadID) []GamepadID {
-	return gamepad.AppendGamepadIDs(gamepadIDs)
+	return appendGamepadIDs(gamepadIDs)
 }
 
 // GamepadIDs returns a slice indicating available gamepad IDs.
@@ -158,7 +194,7 @@ func GamepadIDs() []GamepadID {
 //
 // GamepadAxisCount is concurrent-safe.
 func GamepadAxisCount(id GamepadID) int {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return 0
 	}
@@ -176,7 +212,7 @@ func GamepadAxisNum(id GamepadID) int {
 //
 // GamepadAxisValue is concurrent-safe.
 func GamepadAxisValue(id GamepadID, axis GamepadAxisType) float64 {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return 0
 	}
@@ -194,7 +230,7 @@ func GamepadAxis(id GamepadID, axis GamepadAxisType) float64 {
 //
 // GamepadButtonCount is concurrent-safe.
 func GamepadButtonCount(id GamepadID) int {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return 0
 	}
@@ -220,7 +256,7 @@ func GamepadButtonNum(id GamepadID) int {
 // The relationships between physical buttons and button IDs depend on environments.
 // There can be differences even between Chrome and Firefox.
 func IsGamepadButtonPressed(id GamepadID, button GamepadButton) bool {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return false
 	}
@@ -245,7 +281,7 @@ func IsGamepadButtonPressed(id GamepadID, button GamepadButton) bool {
 //
 // StandardGamepadAxisValue is concurrent safe.
 func StandardGamepadAxisValue(id GamepadID, axis StandardGamepadAxis) float64 {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return 0
 	}
@@ -258,7 +294,7 @@ func StandardGamepadAxisValue(id GamepadID, axis StandardGamepadAxis) float64 {
 //
 // StandardGamepadButtonValue is concurrent safe.
 func StandardGamepadButtonValue(id GamepadID, button StandardGamepadButton) float64 {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return 0
 	}
@@ -271,7 +307,7 @@ func StandardGamepadButtonValue(id GamepadID, button StandardGamepadButton) floa
 //
 // IsStandardGamepadButtonPressed is concurrent safe.
 func IsStandardGamepadButtonPressed(id GamepadID, button StandardGamepadButton) bool {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return false
 	}
@@ -282,7 +318,7 @@ func IsStandardGamepadButtonPressed(id GamepadID, button StandardGamepadButton)
 //
 // IsStandardGamepadLayoutAvailable is concurrent-safe.
 func IsStandardGamepadLayoutAvailable(id GamepadID) bool {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return false
 	}
@@ -293,7 +329,7 @@ func IsStandardGamepadLayoutAvailable(id GamepadID) bool {
 //
 // IsStandardGamepadAxisAvailable is concurrent-safe.
 func IsStandardGamepadAxisAvailable(id GamepadID, axis StandardGamepadAxis) bool {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return false
 	}
@@ -304,7 +340,7 @@ func IsStandardGamepadAxisAvailable(id GamepadID, axis StandardGamepadAxis) bool
 //
 // IsStandardGamepadButtonAvailable is concurrent-safe.
 func IsStandardGamepadButtonAvailable(id GamepadID, button StandardGamepadButton) bool {
-	g := gamepad.Get(id)
+	g := getGamepad(id)
 	if g == nil {
 		return false
 	}
@@ -349,6 +385,21 @@ func UpdateStandardGamepadLayoutMappings(mappings string) (bool, error) {
 	return true, nil
 }
 
+// StandardGamepadLayoutMapping returns the active mapping line in the format of SDL_GameControllerDB for the specified gamepad.
+//
+// StandardGamepadLayoutMapping returns false if the gamepad is not connected,
+// if there is no mapping for the gamepad, or if the mapping is not given as an SDL_GameControllerDB line,
+// e.g. when the mapping is generated by Ebitengine or is managed by the platform.
+//
+// StandardGamepadLayoutMapping is concurrent-safe.
+func StandardGamepadLayoutMapping(id GamepadID) (string, bool) {
+	g := getGamepad(id)
+	if g == nil {
+		return "", false
+	}
+	return gamepaddb.Mapping(g.SDLID())
+}
+
 // TouchID represents a touch's identifier.
 type TouchID = ui.TouchID
 
@@ -382,17 +433,162 @@ func TouchPosition(id TouchID) (int, int) {
 	return theInputState.touchPosition(id)
 }
 
+// TouchForce returns the pressure of the touch of the specified ID, in the range of [0, 1].
+//
+// On browsers, TouchForce returns Touch.force.
+// If the device doesn't detect pressure, or on the other platforms, TouchForce returns 0.
+//
+// If the touch of the specified ID is not present, TouchForce returns 0.
+//
+// TouchForce is concurrent-safe.
+func TouchForce(id TouchID) float64 {
+	return theInputState.touchForce(id)
+}
+
+// TouchRadius returns the radii of the ellipse of the contact area for the touch of the specified ID.
+// The unit is the same as TouchPosition.
+//
+// On browsers, TouchRadius returns Touch.radiusX and Touch.radiusY converted into the logical coordinate.
+// If the device doesn't detect the contact area, or on the other platforms, TouchRadius returns (0, 0).
+//
+// If the touch of the specified ID is not present, TouchRadius returns (0, 0).
+//
+// TouchRadius is concurrent-safe.
+func TouchRadius(id TouchID) (rx, ry float64) {
+	return theInputState.touchRadius(id)
+}
+
 var theInputState inputState
 
 type inputState struct {
-	state ui.InputState
-	m     sync.Mutex
+	state    ui.InputState
+	recorder inputRecorder
+	captured bool
+	m        sync.Mutex
 }
 
 func (i *inputState) update(fn func(*ui.InputState)) {
 	i.m.Lock()
 	defer i.m.Unlock()
 	fn(&i.state)
+	i.recorder.update(&i.state)
+
+	// Suppress the character keys after recording so that a recorded input doesn't depend on the capturing state.
+	if i.captured {
+		for k := range i.state.KeyPressed {
+			if isCharacterKey(ui.Key(k)) {
+				i.state.KeyPressed[k] = false
+			}
+		}
+	}
+}
+
+func (i *inputState) isCaptured() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.captured
+}
+
+func (i *inputState) setCaptured(captured bool) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.captured = captured
+}
+
+// isCharacterKey reports whether the key inputs a character, like a letter, a digit, a symbol, or a space.
+func isCharacterKey(key ui.Key) bool {
+	switch {
+	case ui.KeyA <= key && key <= ui.KeyZ:
+		return true
+	case ui.KeyDigit0 <= key && key <= ui.KeyDigit9:
+		return true
+	case ui.KeyNumpad0 <= key && key <= ui.KeyNumpad9:
+		return true
+	}
+	switch key {
+	case ui.KeyBackquote,
+		ui.KeyBackslash,
+		ui.KeyBracketLeft,
+		ui.KeyBracketRight,
+		ui.KeyComma,
+		ui.KeyEqual,
+		ui.KeyIntlBackslash,
+		ui.KeyMinus,
+		ui.KeyNumpadAdd,
+		ui.KeyNumpadDecimal,
+		ui.KeyNumpadDivide,
+		ui.KeyNumpadEqual,
+		ui.KeyNumpadMultiply,
+		ui.KeyNumpadSubtract,
+		ui.KeyPeriod,
+		ui.KeyQuote,
+		ui.KeySemicolon,
+		ui.KeySlash,
+		ui.KeySpace:
+		return true
+	}
+	return false
+}
+
+func (i *inputState) error() error {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.recorder.err
+}
+
+func (i *inputState) recordInput(w io.Writer) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	if w == nil {
+		i.recorder.encoder = nil
+		return
+	}
+	i.recorder.encoder = gob.NewEncoder(w)
+}
+
+func (i *inputState) replayInput(r io.Reader) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.recorder.replayed = nil
+	if r == nil {
+		i.recorder.decoder = nil
+		return
+	}
+	i.recorder.decoder = gob.NewDecoder(r)
+}
+
+func (i *inputState) isReplaying() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.recorder.decoder != nil
+}
+
+// replayedGamepad returns the replayed gamepad state for id.
+// The second value is false if the input state is not being replayed.
+func (i *inputState) replayedGamepad(id GamepadID) (*recordedGamepad, bool) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	if i.recorder.replayed == nil {
+		return nil, false
+	}
+	for _, g := range i.recorder.replayed.Gamepads {
+		if g.ID == id {
+			return g, true
+		}
+	}
+	return nil, true
+}
+
+func (i *inputState) appendReplayedGamepadIDs(gamepadIDs []GamepadID) ([]GamepadID, bool) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	if i.recorder.replayed == nil {
+		return gamepadIDs, false
+	}
+	for _, g := range i.recorder.replayed.Gamepads {
+		gamepadIDs = append(gamepadIDs, g.ID)
+	}
+	return gamepadIDs, true
 }
 
 func (i *inputState) appendInputChars(runes []rune) []rune {
@@ -464,6 +660,32 @@ func (i *inputState) touchPosition(id TouchID) (int, int) {
 	return 0, 0
 }
 
+func (i *inputState) touchForce(id TouchID) float64 {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for _, t := range i.state.Touches {
+		if id != t.ID {
+			continue
+		}
+		return t.Force
+	}
+	return 0
+}
+
+func (i *inputState) touchRadius(id TouchID) (float64, float64) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for _, t := range i.state.Touches {
+		if id != t.ID {
+			continue
+		}
+		return t.RadiusX, t.RadiusY
+	}
+	return 0, 0
+}
+
 func (i *inputState) windowBeingClosed() bool {
 	i.m.Lock()
 	defer i.m.Unlock()
@@ -0,0 +1,79 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+func TestInputCaptured(t *testing.T) {
+	var s ui.InputState
+	for _, k := range []ebiten.Key{
+		ebiten.KeyW,
+		ebiten.KeyDigit1,
+		ebiten.KeyNumpad5,
+		ebiten.KeySlash,
+		ebiten.KeySpace,
+		ebiten.KeyEscape,
+		ebiten.KeyEnter,
+		ebiten.KeyArrowUp,
+		ebiten.KeyShiftLeft,
+	} {
+		s.KeyPressed[k] = true
+	}
+
+	testCases := []struct {
+		key      ebiten.Key
+		captured bool
+		want     bool
+	}{
+		{key: ebiten.KeyW, captured: false, want: true},
+		{key: ebiten.KeyW, captured: true, want: false},
+		{key: ebiten.KeyDigit1, captured: true, want: false},
+		{key: ebiten.KeyNumpad5, captured: true, want: false},
+		{key: ebiten.KeySlash, captured: true, want: false},
+		{key: ebiten.KeySpace, captured: true, want: false},
+		{key: ebiten.KeyEscape, captured: true, want: true},
+		{key: ebiten.KeyEnter, captured: true, want: true},
+		{key: ebiten.KeyArrowUp, captured: true, want: true},
+		{key: ebiten.KeyShift, captured: true, want: true},
+		{key: ebiten.KeyA, captured: true, want: false},
+	}
+	for _, tc := range testCases {
+		pressed := ebiten.KeyPressedWithInputCapturedForTesting(s, tc.captured)
+		if got := pressed(tc.key); got != tc.want {
+			t.Errorf("captured: %v, key: %s, got: %v, want: %v", tc.captured, tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestSetInputCaptured(t *testing.T) {
+	defer ebiten.SetInputCaptured(false)
+
+	if ebiten.IsInputCaptured() {
+		t.Errorf("IsInputCaptured() must be false by default")
+	}
+	ebiten.SetInputCaptured(true)
+	if !ebiten.IsInputCaptured() {
+		t.Errorf("IsInputCaptured() must be true after SetInputCaptured(true)")
+	}
+	ebiten.SetInputCaptured(false)
+	if ebiten.IsInputCaptured() {
+		t.Errorf("IsInputCaptured() must be false after SetInputCaptured(false)")
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// RecordInput starts recording the input state of each tick to w.
+//
+// The input state includes keys, mouse buttons, the cursor position, the wheel, touches, input characters,
+// gamepads, and whether the window is being closed. Dropped files are not recorded.
+// The state is written at the beginning of each tick, before Update is called.
+//
+// If w is nil, RecordInput stops recording.
+// If writing to w fails, RunGame returns the error.
+//
+// RecordInput is concurrent-safe.
+func RecordInput(w io.Writer) {
+	theInputState.recordInput(w)
+}
+
+// ReplayInput starts replaying the input state of each tick from r, which is recorded by RecordInput.
+//
+// During the replay, the real input is ignored, and the input functions like IsKeyPressed and GamepadAxisValue
+// return the recorded values instead.
+// When r reaches the end, the replay ends and the real input is used again.
+// Combined with a fixed random seed, a game session can be reproduced exactly.
+//
+// If r is nil, ReplayInput stops replaying.
+// If reading r fails, RunGame returns the error.
+//
+// ReplayInput is concurrent-safe.
+func ReplayInput(r io.Reader) {
+	theInputState.replayInput(r)
+}
+
+// IsReplayingInput reports whether the input state is being replayed by ReplayInput.
+//
+// IsReplayingInput is concurrent-safe.
+func IsReplayingInput() bool {
+	return theInputState.isReplaying()
+}
+
+// recordedInput is the input state of one tick for RecordInput and ReplayInput.
+type recordedInput struct {
+	KeyPressed         [ui.KeyMax + 1]bool
+	MouseButtonPressed [ui.MouseButtonMax + 1]bool
+	CursorX            float64
+	CursorY            float64
+	WheelX             float64
+	WheelY             float64
+	Touches            []ui.Touch
+	Runes              []rune
+	WindowBeingClosed  bool
+	Gamepads           []*recordedGamepad
+}
+
+func (r *recordedInput) read(state *ui.InputState) {
+	r.KeyPressed = state.KeyPressed
+	r.MouseButtonPressed = state.MouseButtonPressed
+	r.CursorX = state.CursorX
+	r.CursorY = state.CursorY
+	r.WheelX = state.WheelX
+	r.WheelY = state.WheelY
+	r.Touches = append(r.Touches[:0], state.Touches...)
+	r.Runes = append(r.Runes[:0], state.Runes...)
+	r.WindowBeingClosed = state.WindowBeingClosed
+
+	r.Gamepads = r.Gamepads[:0]
+	for _, id := range gamepad.AppendGamepadIDs(nil) {
+		g := gamepad.Get(id)
+		if g == nil {
+			continue
+		}
+		r.Gamepads = append(r.Gamepads, newRecordedGamepad(id, g))
+	}
+}
+
+func (r *recordedInput) write(state *ui.InputState) {
+	state.KeyPressed = r.KeyPressed
+	state.MouseButtonPressed = r.MouseButtonPressed
+	state.CursorX = r.CursorX
+	state.CursorY = r.CursorY
+	state.WheelX = r.WheelX
+	state.WheelY = r.WheelY
+	state.Touches = append(state.Touches[:0], r.Touches...)
+	state.Runes = append(state.Runes[:0], r.Runes...)
+	state.WindowBeingClosed = r.WindowBeingClosed
+	state.DroppedFiles = nil
+}
+
+// gamepadReader is the interface to read a gamepad state, implemented by *gamepad.Gamepad and *recordedGamepad.
+type gamepadReader interface {
+	Name() string
+	SDLID() string
+	AxisCount() int
+	ButtonCount() int
+	HatCount() int
+	Axis(axis int) float64
+	Button(button int) bool
+	Hat(hat int) int
+	IsStandardLayoutAvailable() bool
+	IsStandardAxisAvailable(axis gamepaddb.StandardAxis) bool
+	IsStandardButtonAvailable(button gamepaddb.StandardButton) bool
+	StandardAxisValue(axis gamepaddb.StandardAxis) float64
+	StandardButtonValue(button gamepaddb.StandardButton) float64
+	IsStandardButtonPressed(button gamepaddb.StandardButton) bool
+}
+
+// getGamepad returns the gamepad state for id. getGamepad returns nil if the gamepad is not found.
+//
+// During a replay, getGamepad returns the recorded gamepad state.
+func getGamepad(id GamepadID) gamepadReader {
+	if g, ok := theInputState.replayedGamepad(id); ok {
+		if g == nil {
+			return nil
+		}
+		return g
+	}
+	if g := gamepad.Get(id); g != nil {
+		return g
+	}
+	return nil
+}
+
+func appendGamepadIDs(gamepadIDs []GamepadID) []GamepadID {
+	if ids, ok := theInputState.appendReplayedGamepadIDs(gamepadIDs); ok {
+		return ids
+	}
+	return gamepad.AppendGamepadIDs(gamepadIDs)
+}
+
+type recordedGamepad struct {
+	ID                       GamepadID
+	GamepadName              string
+	GamepadSDLID             string
+	Axes                     []float64
+	Buttons                  []bool
+	Hats                     []int
+	StandardLayoutAvailable  bool
+	StandardAxesAvailable    [gamepaddb.StandardAxisMax + 1]bool
+	StandardAxes             [gamepaddb.StandardAxisMax + 1]float64
+	StandardButtonsAvailable [gamepaddb.StandardButtonMax + 1]bool
+	StandardButtons          [gamepaddb.StandardButtonMax + 1]float64
+	StandardButtonsPressed   [gamepaddb.StandardButtonMax + 1]bool
+}
+
+func newRecordedGamepad(id GamepadID, g gamepadReader) *recordedGamepad {
+	r := &recordedGamepad{
+		ID:                      id,
+		GamepadName:             g.Name(),
+		GamepadSDLID:            g.SDLID(),
+		Axes:                    make([]float64, g.AxisCount()),
+		Buttons:                 make([]bool, g.ButtonCount()),
+		Hats:                    make([]int, g.HatCount()),
+		StandardLayoutAvailable: g.IsStandardLayoutAvailable(),
+	}
+	for i := range r.Axes {
+		r.Axes[i] = g.Axis(i)
+	}
+	for i := range r.Buttons {
+		r.Buttons[i] = g.Button(i)
+	}
+	for i := range r.Hats {
+		r.Hats[i] = g.Hat(i)
+	}
+	if r.StandardLayoutAvailable {
+		for a := gamepaddb.StandardAxis(0); a <= gamepaddb.StandardAxisMax; a++ {
+			r.StandardAxesAvailable[a] = g.IsStandardAxisAvailable(a)
+			r.StandardAxes[a] = g.StandardAxisValue(a)
+		}
+		for b := gamepaddb.StandardButton(0); b <= gamepaddb.StandardButtonMax; b++ {
+			r.StandardButtonsAvailable[b] = g.IsStandardButtonAvailable(b)
+			r.StandardButtons[b] = g.StandardButtonValue(b)
+			r.StandardButtonsPressed[b] = g.IsStandardButtonPressed(b)
+		}
+	}
+	return r
+}
+
+func (r *recordedGamepad) Name() string {
+	return r.GamepadName
+}
+
+func (r *recordedGamepad) SDLID() string {
+	return r.GamepadSDLID
+}
+
+func (r *recordedGamepad) AxisCount() int {
+	return len(r.Axes)
+}
+
+func (r *recordedGamepad) ButtonCount() int {
+	return len(r.Buttons)
+}
+
+func (r *recordedGamepad) HatCount() int {
+	return len(r.Hats)
+}
+
+func (r *recordedGamepad) Axis(axis int) float64 {
+	if axis < 0 || axis >= len(r.Axes) {
+		return 0
+	}
+	return r.Axes[axis]
+}
+
+func (r *recordedGamepad) Button(button int) bool {
+	if button < 0 || button >= len(r.Buttons) {
+		return false
+	}
+	return r.Buttons[button]
+}
+
+func (r *recordedGamepad) Hat(hat int) int {
+	if hat < 0 || hat >= len(r.Hats) {
+		return 0
+	}
+	return r.Hats[hat]
+}
+
+func (r *recordedGamepad) IsStandardLayoutAvailable() bool {
+	return r.StandardLayoutAvailable
+}
+
+func (r *recordedGamepad) IsStandardAxisAvailable(axis gamepaddb.StandardAxis) bool {
+	if axis < 0 || axis > gamepaddb.StandardAxisMax {
+		return false
+	}
+	return r.StandardAxesAvailable[axis]
+}
+
+func (r *recordedGamepad) IsStandardButtonAvailable(button gamepaddb.StandardButton) bool {
+	if button < 0 || button > gamepaddb.StandardButtonMax {
+		return false
+	}
+	return r.StandardButtonsAvailable[button]
+}
+
+func (r *recordedGamepad) StandardAxisValue(axis gamepaddb.StandardAxis) float64 {
+	if axis < 0 || axis > gamepaddb.StandardAxisMax {
+		return 0
+	}
+	return r.StandardAxes[axis]
+}
+
+func (r *recordedGamepad) StandardButtonValue(button gamepaddb.StandardButton) float64 {
+	if button < 0 || button > gamepaddb.StandardButtonMax {
+		return 0
+	}
+	return r.StandardButtons[button]
+}
+
+func (r *recordedGamepad) IsStandardButtonPressed(button gamepaddb.StandardButton) bool {
+	if button < 0 || button > gamepaddb.StandardButtonMax {
+		return false
+	}
+	return r.StandardButtonsPressed[button]
+}
+
+// inputRecorder records and replays the input states. inputRecorder is not concurrent-safe and is protected by inputState.
+type inputRecorder struct {
+	encoder *gob.Encoder
+	decoder *gob.Decoder
+
+	// replayed is the current replayed input state.
+	replayed *recordedInput
+
+	// recorded is a buffer to record an input state.
+	recorded recordedInput
+
+	err error
+}
+
+func (i *inputRecorder) update(state *ui.InputState) {
+	if i.err != nil {
+		return
+	}
+
+	if i.decoder != nil {
+		var r recordedInput
+		if err := i.decoder.Decode(&r); err != nil {
+			i.decoder = nil
+			i.replayed = nil
+			if !errors.Is(err, io.EOF) {
+				i.err = err
+				return
+			}
+		} else {
+			r.write(state)
+			i.replayed = &r
+		}
+	}
+
+	if i.encoder != nil {
+		if i.replayed != nil {
+			i.err = i.encoder.Encode(i.replayed)
+		} else {
+			i.recorded.read(state)
+			i.err = i.encoder.Encode(&i.recorded)
+		}
+	}
+}
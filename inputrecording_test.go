@@ -0,0 +1,49 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+func TestRecordAndReplayInput(t *testing.T) {
+	var states []ui.InputState
+	for i := 0; i < 3; i++ {
+		var s ui.InputState
+		s.KeyPressed[ebiten.KeyA] = i%2 == 0
+		s.MouseButtonPressed[ebiten.MouseButtonRight] = i == 1
+		s.CursorX = float64(i) * 1.5
+		s.CursorY = float64(i) * 2.5
+		s.WheelY = -1
+		s.Touches = []ui.Touch{
+			{ID: ui.TouchID(i), X: i, Y: 2 * i, Force: 0.5},
+		}
+		s.Runes = []rune{'a' + rune(i)}
+		s.WindowBeingClosed = i == 2
+		states = append(states, s)
+	}
+
+	got, err := ebiten.RecordAndReplayInputStatesForTesting(states)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, states) {
+		t.Errorf("got: %v, want: %v", got, states)
+	}
+}
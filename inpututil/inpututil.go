@@ -313,6 +313,66 @@ func KeyPressDuration(key ebiten.Key) int {
 	return s
 }
 
+// AppendRepeatedKeys append keyboard keys that are repeated in the current tick to keys and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// For the details of the repeat, see IsKeyRepeated.
+//
+// AppendRepeatedKeys must be called in a game's Update, not Draw.
+//
+// AppendRepeatedKeys is concurrent safe.
+func AppendRepeatedKeys(keys []ebiten.Key, delay, interval int) []ebiten.Key {
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+
+	for i, d := range theInputState.keyDurations {
+		if !isRepeated(d, delay, interval) {
+			continue
+		}
+		keys = append(keys, ebiten.Key(i))
+	}
+	return keys
+}
+
+// IsKeyRepeated returns a boolean value indicating
+// whether the given key is repeated in the current tick, in the way of a typical key auto-repeat.
+//
+// IsKeyRepeated returns true when the key is just pressed.
+// While the key is kept pressed, IsKeyRepeated returns true again after delay ticks,
+// and then returns true every interval ticks.
+// For example, with delay 30 and interval 5, IsKeyRepeated returns true at the 1st, 31st, 36th, 41st, ... ticks.
+// delay and interval less than 1 are treated as 1.
+//
+// IsKeyRepeated is useful for menu navigation or text fields.
+//
+// IsKeyRepeated must be called in a game's Update, not Draw.
+//
+// IsKeyRepeated is concurrent safe.
+func IsKeyRepeated(key ebiten.Key, delay, interval int) bool {
+	return isRepeated(KeyPressDuration(key), delay, interval)
+}
+
+// isRepeated reports whether an input pressed for duration ticks is repeated in the current tick.
+func isRepeated(duration int, delay, interval int) bool {
+	if duration <= 0 {
+		return false
+	}
+	if duration == 1 {
+		return true
+	}
+	if delay < 1 {
+		delay = 1
+	}
+	if interval < 1 {
+		interval = 1
+	}
+	d := duration - 1 - delay
+	if d < 0 {
+		return false
+	}
+	return d%interval == 0
+}
+
 // IsMouseButtonJustPressed returns a boolean value indicating
 // whether the given mouse button is pressed just in the current tick.
 //
@@ -646,6 +706,19 @@ func StandardGamepadButtonPressDuration(id ebiten.GamepadID, button ebiten.Stand
 	return 0
 }
 
+// IsStandardGamepadButtonRepeated returns a boolean value indicating
+// whether the given standard gamepad button of the gamepad id is repeated in the current tick,
+// in the way of a typical key auto-repeat.
+//
+// For the details of the repeat, see IsKeyRepeated.
+//
+// IsStandardGamepadButtonRepeated must be called in a game's Update, not Draw.
+//
+// IsStandardGamepadButtonRepeated is concurrent safe.
+func IsStandardGamepadButtonRepeated(id ebiten.GamepadID, button ebiten.StandardGamepadButton, delay, interval int) bool {
+	return isRepeated(StandardGamepadButtonPressDuration(id, button), delay, interval)
+}
+
 // AppendJustPressedTouchIDs append touch IDs that are created just in the current tick to touchIDs,
 // and returns the extended buffer.
 // Giving a slice that already has enough capacity works efficiently.
@@ -0,0 +1,168 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestIsRepeated(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Delay    int
+		Interval int
+		// Ticks is the sequence of the key states for each tick.
+		Ticks string
+		// Want is the sequence of the expected repeats for each tick.
+		Want string
+	}{
+		{
+			Name:     "held",
+			Delay:    3,
+			Interval: 2,
+			Ticks:    "__XXXXXXXXXX__",
+			Want:     "__X__X_X_X_X__",
+		},
+		{
+			Name:     "released before delay",
+			Delay:    3,
+			Interval: 2,
+			Ticks:    "XX_XXX_X",
+			Want:     "X__X___X",
+		},
+		{
+			Name:     "every tick",
+			Delay:    1,
+			Interval: 1,
+			Ticks:    "XXXX_X",
+			Want:     "XXXX_X",
+		},
+		{
+			Name:     "non-positive values",
+			Delay:    0,
+			Interval: -1,
+			Ticks:    "XXXX",
+			Want:     "XXXX",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var duration int
+			for i, c := range tc.Ticks {
+				if c == 'X' {
+					duration++
+				} else {
+					duration = 0
+				}
+				got := isRepeated(duration, tc.Delay, tc.Interval)
+				want := tc.Want[i] == 'X'
+				if got != want {
+					t.Errorf("tick %d: got: %t, want: %t", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGamepadButtonSequence(t *testing.T) {
+	const (
+		up    = ebiten.StandardGamepadButtonLeftTop
+		down  = ebiten.StandardGamepadButtonLeftBottom
+		right = ebiten.StandardGamepadButtonLeftRight
+		punch = ebiten.StandardGamepadButtonRightLeft
+	)
+
+	testCases := []struct {
+		Name string
+		// Ticks is the sequence of the just pressed buttons for each tick.
+		Ticks [][]ebiten.StandardGamepadButton
+		// Want is the tick index when the sequence is completed, or -1.
+		Want int
+	}{
+		{
+			Name:  "completed",
+			Ticks: [][]ebiten.StandardGamepadButton{{down}, nil, {right}, {punch}},
+			Want:  3,
+		},
+		{
+			Name:  "completed at the window",
+			Ticks: [][]ebiten.StandardGamepadButton{{down}, nil, nil, nil, nil, {right}, nil, nil, nil, nil, {punch}},
+			Want:  10,
+		},
+		{
+			Name:  "timed out",
+			Ticks: [][]ebiten.StandardGamepadButton{{down}, nil, nil, nil, nil, {right}, nil, nil, nil, nil, nil, {punch}},
+			Want:  -1,
+		},
+		{
+			Name:  "wrong button",
+			Ticks: [][]ebiten.StandardGamepadButton{{down}, {up}, {right}, {punch}},
+			Want:  -1,
+		},
+		{
+			Name:  "restarted",
+			Ticks: [][]ebiten.StandardGamepadButton{{down}, {down}, {right}, {punch}},
+			Want:  3,
+		},
+		{
+			Name:  "in one tick",
+			Ticks: [][]ebiten.StandardGamepadButton{{down}, {right, punch}},
+			Want:  1,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			seq := &GamepadButtonSequence{
+				Buttons: []ebiten.StandardGamepadButton{down, right, punch},
+				Window:  10,
+			}
+			got := -1
+			for i, buttons := range tc.Ticks {
+				if seq.update(0, buttons) {
+					if got != -1 {
+						t.Errorf("the sequence is completed twice at %d and %d", got, i)
+					}
+					got = i
+				}
+			}
+			if got != tc.Want {
+				t.Errorf("got: %d, want: %d", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestGamepadButtonSequencePerGamepad(t *testing.T) {
+	seq := &GamepadButtonSequence{
+		Buttons: []ebiten.StandardGamepadButton{
+			ebiten.StandardGamepadButtonRightBottom,
+			ebiten.StandardGamepadButtonRightRight,
+		},
+	}
+	if seq.update(0, []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}) {
+		t.Errorf("gamepad 0: got: true, want: false")
+	}
+	// The progress of the gamepad 0 must not affect the gamepad 1.
+	if seq.update(1, []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightRight}) {
+		t.Errorf("gamepad 1: got: true, want: false")
+	}
+	if !seq.update(0, []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightRight}) {
+		t.Errorf("gamepad 0: got: false, want: true")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// GamepadButtonSequence detects an ordered sequence of standard gamepad buttons, like a command input in fighting games.
+//
+// The progress of the sequence is tracked for each gamepad independently.
+//
+// GamepadButtonSequence is not concurrent safe.
+type GamepadButtonSequence struct {
+	// Buttons is the sequence of buttons to be pressed in order.
+	Buttons []ebiten.StandardGamepadButton
+
+	// Window is the maximum number of ticks from the first button press to the last button press of the sequence.
+	// If the sequence is not completed within Window ticks, the progress is reset.
+	// If Window is 0 or negative, the sequence never times out.
+	Window int
+
+	states map[ebiten.GamepadID]*gamepadButtonSequenceState
+}
+
+type gamepadButtonSequenceState struct {
+	// progress is the number of the buttons matched so far.
+	progress int
+
+	// elapsed is the number of ticks since the first button of the sequence is pressed.
+	elapsed int
+}
+
+// Update updates the progress of the sequence for the gamepad id with the buttons just pressed in the current tick,
+// and returns true when the sequence is completed in the current tick.
+//
+// Pressing a button that doesn't match the next button of the sequence resets the progress.
+// If the button matches the first button of the sequence, the sequence starts again from the button.
+// Buttons pressed in the same tick are processed in the order of the button values.
+// When the gamepad is disconnected, the progress for the gamepad is discarded.
+//
+// Update must be called once every tick in a game's Update for each gamepad to track, not Draw.
+func (g *GamepadButtonSequence) Update(id ebiten.GamepadID) bool {
+	if IsGamepadJustDisconnected(id) {
+		delete(g.states, id)
+		return false
+	}
+	return g.update(id, AppendJustPressedStandardGamepadButtons(id, nil))
+}
+
+func (g *GamepadButtonSequence) update(id ebiten.GamepadID, justPressedButtons []ebiten.StandardGamepadButton) bool {
+	if len(g.Buttons) == 0 {
+		return false
+	}
+
+	if g.states == nil {
+		g.states = map[ebiten.GamepadID]*gamepadButtonSequenceState{}
+	}
+	s, ok := g.states[id]
+	if !ok {
+		s = &gamepadButtonSequenceState{}
+		g.states[id] = s
+	}
+
+	if s.progress > 0 {
+		s.elapsed++
+		if g.Window > 0 && s.elapsed > g.Window {
+			s.progress = 0
+		}
+	}
+
+	for _, b := range justPressedButtons {
+		if b != g.Buttons[s.progress] {
+			s.progress = 0
+			if b != g.Buttons[0] {
+				continue
+			}
+		}
+		if s.progress == 0 {
+			s.elapsed = 0
+		}
+		s.progress++
+		if s.progress == len(g.Buttons) {
+			s.progress = 0
+			return true
+		}
+	}
+	return false
+}
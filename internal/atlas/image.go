@@ -940,6 +940,23 @@ func BeginFrame(graphicsDriver graphicsdriver.Graphics) error {
 	return nil
 }
 
+// TextureMemoryUsage returns the approximate number of bytes of the textures allocated for the atlases and the isolated images.
+func TextureMemoryUsage() int {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	var bytes int
+	for _, b := range theBackends {
+		// The image of an isolated backend is nil after the image is deallocated.
+		if b.image == nil {
+			continue
+		}
+		w, h := b.image.InternalSize()
+		bytes += 4 * w * h
+	}
+	return bytes
+}
+
 func DumpImages(graphicsDriver graphicsdriver.Graphics, dir string) (string, error) {
 	backendsM.Lock()
 	defer backendsM.Unlock()
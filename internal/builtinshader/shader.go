@@ -26,9 +26,10 @@ type Filter int
 const (
 	FilterNearest Filter = iota
 	FilterLinear
+	FilterBicubic
 )
 
-const FilterCount = 2
+const FilterCount = 3
 
 type Address int
 
@@ -36,9 +37,10 @@ const (
 	AddressUnsafe Address = iota
 	AddressClampToZero
 	AddressRepeat
+	AddressMirrorRepeat
 )
 
-const AddressCount = 3
+const AddressCount = 4
 
 const (
 	UniformColorMBody        = "ColorMBody"
@@ -65,6 +67,43 @@ func adjustTexelForAddressRepeat(p vec2) vec2 {
 	size := imageSrc0Size()
 	return mod(p - origin, size) + origin
 }
+{{else if eq .Address .AddressMirrorRepeat}}
+func adjustTexelForAddressMirrorRepeat(p vec2) vec2 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	return size - abs(mod(p - origin, 2*size) - size) + origin
+}
+{{end}}
+
+{{if eq .Filter .FilterBicubic}}
+func bicubicSrcAt(p vec2) vec4 {
+{{if eq .Address .AddressUnsafe}}
+	// Clamp the position to the source region so that the taps don't read texels of other images.
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	return imageSrc0UnsafeAt(clamp(p, origin + 1/2.0, origin + size - 1/2.0))
+{{else if eq .Address .AddressClampToZero}}
+	return imageSrc0At(p)
+{{else if eq .Address .AddressRepeat}}
+	return imageSrc0At(adjustTexelForAddressRepeat(p))
+{{else if eq .Address .AddressMirrorRepeat}}
+	return imageSrc0At(adjustTexelForAddressMirrorRepeat(p))
+{{end}}
+}
+
+func bicubicRow(p vec2, w vec4) vec4 {
+	return w.x*bicubicSrcAt(p - vec2(1, 0)) + w.y*bicubicSrcAt(p) + w.z*bicubicSrcAt(p + vec2(1, 0)) + w.w*bicubicSrcAt(p + vec2(2, 0))
+}
+
+// catmullRomWeights returns the weights of the four texels for the Catmull-Rom spline at t in [0, 1).
+func catmullRomWeights(t float) vec4 {
+	return vec4(
+		t*(-1/2.0 + t*(1 - t/2)),
+		1 + t*t*(-5/2.0 + 3/2.0*t),
+		t*(1/2.0 + t*(2 - 3/2.0*t)),
+		t*t*(-1/2.0 + t/2),
+	)
+}
 {{end}}
 
 func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
@@ -75,6 +114,8 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	clr := imageSrc0At(srcPos)
 {{else if eq .Address .AddressRepeat}}
 	clr := imageSrc0At(adjustTexelForAddressRepeat(srcPos))
+{{else if eq .Address .AddressMirrorRepeat}}
+	clr := imageSrc0At(adjustTexelForAddressMirrorRepeat(srcPos))
 {{end}}
 {{else if eq .Filter .FilterLinear}}
 	p0 := srcPos - 1/2.0
@@ -83,6 +124,10 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 {{if eq .Address .AddressRepeat}}
 	p0 = adjustTexelForAddressRepeat(p0)
 	p1 = adjustTexelForAddressRepeat(p1)
+{{else if eq .Address .AddressMirrorRepeat}}
+	// In a mirrored region, p0 and p1 are swapped, and so are the weights. Then, the result is still correct.
+	p0 = adjustTexelForAddressMirrorRepeat(p0)
+	p1 = adjustTexelForAddressMirrorRepeat(p1)
 {{end}}
 
 {{if eq .Address .AddressUnsafe}}
@@ -99,6 +144,17 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 
 	rate := fract(p1)
 	clr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+{{else if eq .Filter .FilterBicubic}}
+	p := srcPos - 1/2.0
+	rate := fract(p)
+	// c is the center of the texel at the upper-left of p.
+	c := p - rate + 1/2.0
+	wx := catmullRomWeights(rate.x)
+	wy := catmullRomWeights(rate.y)
+	clr := wy.x*bicubicRow(c - vec2(0, 1), wx) + wy.y*bicubicRow(c, wx) + wy.z*bicubicRow(c + vec2(0, 1), wx) + wy.w*bicubicRow(c + vec2(0, 2), wx)
+	// The Catmull-Rom spline can overshoot. Keep the color valid as a premultiplied-alpha color.
+	clr = clamp(clr, 0, 1)
+	clr.rgb = min(clr.rgb, clr.a)
 {{end}}
 
 {{if .UseColorM}}
@@ -140,23 +196,27 @@ func ShaderSource(filter Filter, address Address, useColorM bool) []byte {
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, struct {
-		Filter             Filter
-		FilterNearest      Filter
-		FilterLinear       Filter
-		Address            Address
-		AddressUnsafe      Address
-		AddressClampToZero Address
-		AddressRepeat      Address
-		UseColorM          bool
+		Filter              Filter
+		FilterNearest       Filter
+		FilterLinear        Filter
+		FilterBicubic       Filter
+		Address             Address
+		AddressUnsafe       Address
+		AddressClampToZero  Address
+		AddressRepeat       Address
+		AddressMirrorRepeat Address
+		UseColorM           bool
 	}{
-		Filter:             filter,
-		FilterNearest:      FilterNearest,
-		FilterLinear:       FilterLinear,
-		Address:            address,
-		AddressUnsafe:      AddressUnsafe,
-		AddressClampToZero: AddressClampToZero,
-		AddressRepeat:      AddressRepeat,
-		UseColorM:          useColorM,
+		Filter:              filter,
+		FilterNearest:       FilterNearest,
+		FilterLinear:        FilterLinear,
+		FilterBicubic:       FilterBicubic,
+		Address:             address,
+		AddressUnsafe:       AddressUnsafe,
+		AddressClampToZero:  AddressClampToZero,
+		AddressRepeat:       AddressRepeat,
+		AddressMirrorRepeat: AddressMirrorRepeat,
+		UseColorM:           useColorM,
 	}); err != nil {
 		panic(fmt.Sprintf("builtinshader: tmpl.Execute failed: %v", err))
 	}
@@ -16,6 +16,7 @@
 package clock
 
 import (
+	"math"
 	"sync"
 	"time"
 )
@@ -35,6 +36,15 @@ var (
 	// lastSystemTime indicates the logical time in the game, so this can be bigger than the current time.
 	lastSystemTime int64
 
+	theFrameTimes frameTimes
+
+	// frameStarted reports whether UpdateFrame has been called at least once.
+	// The first frame time is not recorded, as the time before the first frame includes the startup.
+	frameStarted bool
+
+	// interpolation represents how far the current frame is between the previous tick and the current tick.
+	interpolation float64
+
 	actualFPS   float64
 	actualTPS   float64
 	prevTPS     int64
@@ -64,6 +74,18 @@ func ActualTPS() float64 {
 	return actualTPS
 }
 
+func ActualFrameStats() FrameStats {
+	m.Lock()
+	defer m.Unlock()
+	return theFrameTimes.stats()
+}
+
+func Interpolation() float64 {
+	m.Lock()
+	defer m.Unlock()
+	return interpolation
+}
+
 func max(a, b int64) int64 {
 	if a < b {
 		return b
@@ -117,6 +139,26 @@ func calcCountFromTPS(tps int64, now int64) int {
 	return count
 }
 
+// calcInterpolation returns how far the current frame is between the previous tick and the current tick.
+//
+// The value is derived from lastSystemTime, the logical time of the current tick, so that the interpolated position
+// (the number of the ticks - 1 + the interpolation) never goes backward.
+// As the count is stabilized, lastSystemTime can be ahead of now. In this case, the frame is regarded as
+// between the previous tick and the current tick.
+//
+// calcInterpolation must be called after calcCountFromTPS.
+func calcInterpolation(tps int64, now int64, lastSystemTime int64) float64 {
+	tick := float64(time.Second) / float64(tps)
+	v := 1 - float64(lastSystemTime-now)/tick
+	if v < 0 {
+		return 0
+	}
+	if v >= 1 {
+		return math.Nextafter(1, 0)
+	}
+	return v
+}
+
 func updateFPSAndTPS(now int64, count int) {
 	fpsCount++
 	tpsCount += count
@@ -149,13 +191,19 @@ func UpdateFrame() int {
 		// This ensures that now() must be monotonic (#875).
 		panic("clock: lastNow must be older than n")
 	}
+	if frameStarted {
+		theFrameTimes.record(time.Duration(n - lastNow))
+	}
+	frameStarted = true
 	lastNow = n
 
 	c := 0
+	interpolation = 0
 	if tps == SyncWithFPS {
 		c = 1
 	} else if tps > 0 {
 		c = calcCountFromTPS(int64(tps), n)
+		interpolation = calcInterpolation(int64(tps), n, lastSystemTime)
 	}
 	updateFPSAndTPS(n, c)
 
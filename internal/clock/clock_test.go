@@ -0,0 +1,100 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCalcInterpolation(t *testing.T) {
+	const tick = 10 * time.Millisecond
+
+	testCases := []struct {
+		Name           string
+		Now            time.Duration
+		LastSystemTime time.Duration
+		Interpolation  float64
+	}{
+		{
+			Name:           "at the current tick",
+			Now:            tick,
+			LastSystemTime: tick,
+			Interpolation:  math.Nextafter(1, 0),
+		},
+		{
+			Name:           "half a tick before the current tick",
+			Now:            tick / 2,
+			LastSystemTime: tick,
+			Interpolation:  0.5,
+		},
+		{
+			Name:           "a tick before the current tick",
+			Now:            0,
+			LastSystemTime: tick,
+			Interpolation:  0,
+		},
+		{
+			Name:           "more than a tick before the current tick",
+			Now:            0,
+			LastSystemTime: 2 * tick,
+			Interpolation:  0,
+		},
+		{
+			Name:           "after the current tick",
+			Now:            2 * tick,
+			LastSystemTime: tick,
+			Interpolation:  math.Nextafter(1, 0),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			got := calcInterpolation(100, int64(tc.Now), int64(tc.LastSystemTime))
+			if math.Abs(got-tc.Interpolation) > 1e-9 {
+				t.Errorf("got: %f, want: %f", got, tc.Interpolation)
+			}
+		})
+	}
+}
+
+func TestInterpolatedPositionMonotonic(t *testing.T) {
+	origLastSystemTime, origPrevTPS := lastSystemTime, prevTPS
+	defer func() {
+		lastSystemTime, prevTPS = origLastSystemTime, origPrevTPS
+	}()
+
+	const tps = 60
+	for _, fps := range []int64{30, 60, 120, 144, 240} {
+		lastSystemTime = 0
+		prevTPS = tps
+
+		var ticks int
+		prev := math.Inf(-1)
+		for i := int64(1); i <= 3*fps; i++ {
+			now := i * int64(time.Second) / fps
+			ticks += calcCountFromTPS(tps, now)
+			alpha := calcInterpolation(tps, now, lastSystemTime)
+
+			// The position in ticks that is rendered by interpolating the previous and the current ticks.
+			pos := float64(ticks-1) + alpha
+			if pos < prev {
+				t.Errorf("FPS: %d, frame: %d: the position went backward: %f -> %f", fps, i, prev, pos)
+			}
+			prev = pos
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"sort"
+	"time"
+)
+
+// frameTimeCount is the number of the recent frames to calculate the frame statistics.
+const frameTimeCount = 120
+
+type FrameStats struct {
+	FrameCount        int
+	Min               time.Duration
+	Max               time.Duration
+	Average           time.Duration
+	P50               time.Duration
+	P95               time.Duration
+	P99               time.Duration
+	DroppedFrameCount int
+}
+
+// frameTimes is a ring buffer of the recent frame durations.
+type frameTimes struct {
+	durations [frameTimeCount]time.Duration
+	index     int
+	count     int
+}
+
+func (f *frameTimes) record(d time.Duration) {
+	f.durations[f.index] = d
+	f.index = (f.index + 1) % len(f.durations)
+	if f.count < len(f.durations) {
+		f.count++
+	}
+}
+
+func (f *frameTimes) stats() FrameStats {
+	if f.count == 0 {
+		return FrameStats{}
+	}
+
+	ds := make([]time.Duration, f.count)
+	copy(ds, f.durations[:f.count])
+	sort.Slice(ds, func(i, j int) bool {
+		return ds[i] < ds[j]
+	})
+
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+
+	percentile := func(p int) time.Duration {
+		// Use the nearest-rank method.
+		i := (len(ds)*p + 99) / 100
+		if i < 1 {
+			i = 1
+		}
+		return ds[i-1]
+	}
+
+	// A frame taking more than 1.5 times the median is regarded as dropped.
+	p50 := percentile(50)
+	var dropped int
+	for _, d := range ds {
+		if d*2 > p50*3 {
+			dropped++
+		}
+	}
+
+	return FrameStats{
+		FrameCount:        len(ds),
+		Min:               ds[0],
+		Max:               ds[len(ds)-1],
+		Average:           sum / time.Duration(len(ds)),
+		P50:               p50,
+		P95:               percentile(95),
+		P99:               percentile(99),
+		DroppedFrameCount: dropped,
+	}
+}
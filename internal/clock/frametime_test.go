@@ -0,0 +1,79 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrameStats(t *testing.T) {
+	var f frameTimes
+	if got, want := f.stats(), (FrameStats{}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// 100 frames of 10ms and 20 frames of 30ms, after frames that should be overwritten.
+	for i := 0; i < 50; i++ {
+		f.record(time.Second)
+	}
+	for i := 0; i < frameTimeCount; i++ {
+		d := 10 * time.Millisecond
+		if i%6 == 0 {
+			d = 30 * time.Millisecond
+		}
+		f.record(d)
+	}
+
+	got := f.stats()
+	want := FrameStats{
+		FrameCount:        120,
+		Min:               10 * time.Millisecond,
+		Max:               30 * time.Millisecond,
+		Average:           (100*10*time.Millisecond + 20*30*time.Millisecond) / 120,
+		P50:               10 * time.Millisecond,
+		P95:               30 * time.Millisecond,
+		P99:               30 * time.Millisecond,
+		DroppedFrameCount: 20,
+	}
+	if got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestFrameStatsFirstFrame(t *testing.T) {
+	// The time before the first frame includes the startup and must not be recorded.
+	UpdateFrame()
+	if got, want := ActualFrameStats().FrameCount, 0; got != want {
+		t.Errorf("FrameCount: got: %d, want: %d", got, want)
+	}
+	UpdateFrame()
+	if got, want := ActualFrameStats().FrameCount, 1; got != want {
+		t.Errorf("FrameCount: got: %d, want: %d", got, want)
+	}
+}
+
+func BenchmarkFrameTimesRecord(b *testing.B) {
+	var f frameTimes
+	for i := 0; i < b.N; i++ {
+		f.record(time.Duration(i))
+	}
+}
+
+func BenchmarkUpdateFrame(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		UpdateFrame()
+	}
+}
@@ -248,6 +248,7 @@ type nativeGamepad interface {
 	buttonValue(button int) float64
 	isButtonPressed(button int) bool
 	hatState(hat int) int
+	isVibrationSupported() bool
 	vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64)
 }
 
@@ -410,10 +411,32 @@ func (g *Gamepad) IsStandardButtonPressed(button gamepaddb.StandardButton) bool
 	return false
 }
 
+// IsVibrationSupported is concurrent-safe.
+func (g *Gamepad) IsVibrationSupported() bool {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	return g.native.isVibrationSupported()
+}
+
 // Vibrate is concurrent-safe.
 func (g *Gamepad) Vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	g.m.Lock()
 	defer g.m.Unlock()
 
-	g.native.vibrate(duration, strongMagnitude, weakMagnitude)
+	if duration <= 0 {
+		strongMagnitude = 0
+		weakMagnitude = 0
+	}
+	g.native.vibrate(duration, clampMagnitude(strongMagnitude), clampMagnitude(weakMagnitude))
+}
+
+func clampMagnitude(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
 }
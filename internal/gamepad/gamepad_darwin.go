@@ -436,6 +436,11 @@ func (g *nativeGamepadImpl) hatState(hat int) int {
 	return g.hatValues[hat]
 }
 
+func (g *nativeGamepadImpl) isVibrationSupported() bool {
+	// TODO: Implement this (#1452)
+	return false
+}
+
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	// TODO: Implement this (#1452)
 }
@@ -106,6 +106,7 @@ type nativeGamepadsDesktop struct {
 	procDirectInput8Create    uintptr
 	procXInputGetCapabilities uintptr
 	procXInputGetState        uintptr
+	procXInputSetState        uintptr
 
 	origWndProc         uintptr
 	wndProcCallback     uintptr
@@ -169,6 +170,13 @@ func (g *nativeGamepadsDesktop) init(gamepads *gamepads) error {
 				}
 				g.procXInputGetState = p
 			}
+			{
+				p, err := windows.GetProcAddress(h, "XInputSetState")
+				if err != nil {
+					return err
+				}
+				g.procXInputSetState = p
+			}
 			break
 		}
 	}
@@ -224,6 +232,16 @@ func (g *nativeGamepadsDesktop) xinputGetState(dwUserIndex uint32, pState *_XINP
 	return nil
 }
 
+func (g *nativeGamepadsDesktop) xinputSetState(dwUserIndex uint32, pVibration *_XINPUT_VIBRATION) error {
+	// XInputSetState doesn't call SetLastError and returns an error code directly.
+	r, _, _ := syscall.Syscall(g.procXInputSetState, 2,
+		uintptr(dwUserIndex), uintptr(unsafe.Pointer(pVibration)), 0)
+	if e := syscall.Errno(uint32(r)); e != windows.ERROR_SUCCESS {
+		return fmt.Errorf("gamepad: XInputSetState failed: %w", e)
+	}
+	return nil
+}
+
 func (g *nativeGamepadsDesktop) detectConnection(gamepads *gamepads) error {
 	if g.dinput8 != 0 {
 		if g.enumDevicesCallback == 0 {
@@ -569,6 +587,9 @@ type nativeGamepadDesktop struct {
 
 	xinputIndex int
 	xinputState _XINPUT_STATE
+
+	vib    bool
+	vibEnd time.Time
 }
 
 func (*nativeGamepadDesktop) hasOwnStandardLayoutMapping() bool {
@@ -695,6 +716,16 @@ func (g *nativeGamepadDesktop) update(gamepads *gamepads) (err error) {
 		return nil
 	}
 	g.xinputState = state
+
+	if g.vib && time.Now().Sub(g.vibEnd) >= 0 {
+		if err := gamepads.native.(*nativeGamepadsDesktop).xinputSetState(uint32(g.xinputIndex), &_XINPUT_VIBRATION{}); err != nil {
+			if !errors.Is(err, windows.ERROR_DEVICE_NOT_CONNECTED) {
+				return err
+			}
+		}
+		g.vib = false
+	}
+
 	return nil
 }
 
@@ -807,6 +838,26 @@ func (g *nativeGamepadDesktop) hatState(hat int) int {
 	return v
 }
 
+func (g *nativeGamepadDesktop) isVibrationSupported() bool {
+	// DirectInput force feedback is not supported yet (#1452).
+	return !g.usesDInput() && theGamepads.native.(*nativeGamepadsDesktop).procXInputSetState != 0
+}
+
 func (g *nativeGamepadDesktop) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
-	// TODO: Implement this (#1452)
+	if !g.isVibrationSupported() {
+		return
+	}
+
+	if strongMagnitude <= 0 && weakMagnitude <= 0 {
+		g.vib = false
+		// An error is ignored as there is nothing to do when the device is disconnected.
+		_ = theGamepads.native.(*nativeGamepadsDesktop).xinputSetState(uint32(g.xinputIndex), &_XINPUT_VIBRATION{})
+		return
+	}
+	g.vib = true
+	g.vibEnd = time.Now().Add(duration)
+	_ = theGamepads.native.(*nativeGamepadsDesktop).xinputSetState(uint32(g.xinputIndex), &_XINPUT_VIBRATION{
+		wLeftMotorSpeed:  uint16(strongMagnitude * 0xffff),
+		wRightMotorSpeed: uint16(weakMagnitude * 0xffff),
+	})
 }
@@ -184,6 +184,16 @@ func (g *nativeGamepadImpl) hatState(hat int) int {
 	return hatCentered
 }
 
+func (g *nativeGamepadImpl) isVibrationSupported() bool {
+	if va := g.value.Get("vibrationActuator"); va.Truthy() && va.Get("playEffect").Truthy() {
+		return true
+	}
+	if ha := g.value.Get("hapticActuators"); ha.Truthy() && ha.Length() > 0 {
+		return true
+	}
+	return false
+}
+
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	// vibrationActuator is available on Chrome.
 	if va := g.value.Get("vibrationActuator"); va.Truthy() {
@@ -191,6 +201,11 @@ func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude floa
 			return
 		}
 
+		if strongMagnitude <= 0 && weakMagnitude <= 0 && va.Get("reset").Truthy() {
+			va.Call("reset")
+			return
+		}
+
 		prop := object.New()
 		prop.Set("startDelay", 0)
 		prop.Set("duration", float64(duration/time.Millisecond))
@@ -175,6 +175,10 @@ func (*nativeGamepadImpl) hatState(hat int) int {
 	return hatCentered
 }
 
+func (g *nativeGamepadImpl) isVibrationSupported() bool {
+	return true
+}
+
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	C.ebitengine_VibrateGamepad(C.int(g.id), C.double(float64(duration)/float64(time.Second)), C.double(strongMagnitude), C.double(weakMagnitude))
 }
@@ -86,5 +86,9 @@ func (*nativeGamepadImpl) hatState(hat int) int {
 	return hatCentered
 }
 
+func (g *nativeGamepadImpl) isVibrationSupported() bool {
+	return false
+}
+
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 }
@@ -247,6 +247,10 @@ func (n *nativeGamepadXbox) hatState(hat int) int {
 	return 0
 }
 
+func (n *nativeGamepadXbox) isVibrationSupported() bool {
+	return true
+}
+
 func (n *nativeGamepadXbox) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
 	if strongMagnitude <= 0 && weakMagnitude <= 0 {
 		n.vib = false
@@ -83,6 +83,7 @@ var (
 	gamepadNames          = map[string]string{}
 	gamepadButtonMappings = map[string]map[StandardButton]mapping{}
 	gamepadAxisMappings   = map[string]map[StandardAxis]mapping{}
+	gamepadMappingLines   = map[string]string{}
 	mappingsM             sync.RWMutex
 )
 
@@ -96,7 +97,7 @@ func parseLine(line string, platform platform) (id string, name string, buttons
 	}
 	tokens := strings.Split(line, ",")
 	if len(tokens) < 2 {
-		return "", "", nil, nil, fmt.Errorf("gamepaddb: syntax error")
+		return "", "", nil, nil, fmt.Errorf("gamepaddb: syntax error: a GUID and a name are required: %q", line)
 	}
 
 	for _, token := range tokens[2:] {
@@ -105,7 +106,7 @@ func parseLine(line string, platform platform) (id string, name string, buttons
 		}
 		tks := strings.Split(token, ":")
 		if len(tks) < 2 {
-			return "", "", nil, nil, fmt.Errorf("gamepaddb: syntax error")
+			return "", "", nil, nil, fmt.Errorf("gamepaddb: syntax error: an element must be in the form of key:value: %q", token)
 		}
 
 		// Note that the platform part is listed in the definition of SDL_GetPlatform.
@@ -357,6 +358,16 @@ func Name(id string) string {
 	return gamepadNames[id]
 }
 
+// Mapping returns the mapping line in the format of SDL_GameControllerDB for the given ID.
+// Mapping returns false if there is no such line, e.g. when the mapping is generated by Ebitengine.
+func Mapping(id string) (string, bool) {
+	mappingsM.RLock()
+	defer mappingsM.RUnlock()
+
+	line, ok := gamepadMappingLines[id]
+	return line, ok
+}
+
 func HasStandardAxis(id string, axis StandardAxis) bool {
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
@@ -515,6 +526,7 @@ func Update(mappingData []byte) error {
 	s := bufio.NewScanner(buf)
 
 	type parsedLine struct {
+		line    string
 		id      string
 		name    string
 		buttons map[StandardButton]mapping
@@ -522,14 +534,17 @@ func Update(mappingData []byte) error {
 	}
 	var lines []parsedLine
 
+	var lineno int
 	for s.Scan() {
+		lineno++
 		line := s.Text()
 		id, name, buttons, axes, err := parseLine(line, currentPlatform())
 		if err != nil {
-			return err
+			return fmt.Errorf("%w at line %d", err, lineno)
 		}
 		if id != "" {
 			lines = append(lines, parsedLine{
+				line:    strings.TrimSpace(line),
 				id:      id,
 				name:    name,
 				buttons: buttons,
@@ -546,6 +561,7 @@ func Update(mappingData []byte) error {
 		gamepadNames[l.id] = l.name
 		gamepadButtonMappings[l.id] = l.buttons
 		gamepadAxisMappings[l.id] = l.axes
+		gamepadMappingLines[l.id] = l.line
 	}
 
 	return nil
@@ -80,3 +80,29 @@ func TestGLFWGamepadMappings(t *testing.T) {
 		t.Errorf("got: %q, want: %q", got, want)
 	}
 }
+
+func TestMapping(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef"
+	if _, ok := gamepaddb.Mapping(id); ok {
+		t.Errorf("Mapping(%q) should not exist", id)
+	}
+
+	const line = id + ",Test Gamepad,a:b0,b:b1,leftx:a0,"
+	if err := gamepaddb.Update([]byte("# comment\n  " + line + "  \n")); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := gamepaddb.Mapping(id)
+	if !ok {
+		t.Fatalf("Mapping(%q) should exist", id)
+	}
+	if want := line; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	if err := gamepaddb.Update([]byte(id + ",Test Gamepad,a:b1\n" + id + ",Broken,a:x0")); err == nil {
+		t.Errorf("Update should return an error but not")
+	}
+	if got, _ := gamepaddb.Mapping(id); got != line {
+		t.Errorf("got: %q, want: %q", got, line)
+	}
+}
@@ -7,6 +7,7 @@ package glfw
 
 import (
 	"fmt"
+	"image"
 	"math"
 )
 
@@ -342,6 +343,24 @@ func (w *Window) SetCursorPos(xpos, ypos float64) error {
 	}
 }
 
+// CreateCursor creates a new custom cursor image that can be set for a window with SetCursor.
+// The cursor hotspot is specified in pixels, relative to the upper-left corner of the cursor image.
+func CreateCursor(img image.Image, xhot, yhot int) (*Cursor, error) {
+	if !_glfw.initialized {
+		return nil, NotInitialized
+	}
+
+	cursor := &Cursor{}
+	_glfw.cursors = append(_glfw.cursors, cursor)
+
+	if err := cursor.platformCreateCursor(toGLFWImage(img), xhot, yhot); err != nil {
+		_ = cursor.Destroy()
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
 func CreateStandardCursor(shape StandardCursor) (*Cursor, error) {
 	if !_glfw.initialized {
 		return nil, NotInitialized
@@ -51,17 +51,87 @@ func (w *Window) getWindowExStyle() uint32 {
 	return style
 }
 
+// chooseImage chooses the smallest image that is not smaller than the given size, and scales it down to the size.
+// If there is no such image, chooseImage chooses the largest image as it is.
+//
+// Picking a bigger image and scaling it down with a box filter gives a sharper result than
+// letting the system scale an image with a nearest filter.
 func chooseImage(images []*Image, width, height int) *Image {
-	var leastDiff uint = math.MaxUint32
-	var closest *Image
+	var chosen *Image
 	for _, image := range images {
-		currDiff := abs(image.Width*image.Height - width*height)
-		if currDiff < leastDiff {
-			closest = image
-			leastDiff = currDiff
+		if chosen == nil {
+			chosen = image
+			continue
+		}
+		imageFits := image.Width >= width && image.Height >= height
+		chosenFits := chosen.Width >= width && chosen.Height >= height
+		switch {
+		case imageFits && !chosenFits:
+			chosen = image
+		case imageFits && chosenFits && image.Width*image.Height < chosen.Width*chosen.Height:
+			chosen = image
+		case !imageFits && !chosenFits && image.Width*image.Height > chosen.Width*chosen.Height:
+			chosen = image
+		}
+	}
+	if chosen == nil {
+		return nil
+	}
+	return downscaleImage(chosen, width, height)
+}
+
+// downscaleImage scales the image down to the given size with a box filter.
+// If the image is not bigger than the given size, downscaleImage returns the image as it is.
+func downscaleImage(img *Image, width, height int) *Image {
+	if img.Width < width || img.Height < height {
+		return img
+	}
+	if img.Width == width && img.Height == height {
+		return img
+	}
+
+	pixels := make([]byte, 4*width*height)
+	sx := float64(img.Width) / float64(width)
+	sy := float64(img.Height) / float64(height)
+	for j := 0; j < height; j++ {
+		y0, y1 := float64(j)*sy, float64(j+1)*sy
+		for i := 0; i < width; i++ {
+			x0, x1 := float64(i)*sx, float64(i+1)*sx
+
+			// The pixels have non-premultiplied alpha values. Weight the colors by the alpha values.
+			var r, g, b, a, total float64
+			for y := int(y0); float64(y) < y1 && y < img.Height; y++ {
+				wy := math.Min(y1, float64(y+1)) - math.Max(y0, float64(y))
+				for x := int(x0); float64(x) < x1 && x < img.Width; x++ {
+					wx := math.Min(x1, float64(x+1)) - math.Max(x0, float64(x))
+					w := wx * wy
+					p := img.Pixels[4*(y*img.Width+x) : 4*(y*img.Width+x)+4]
+					wa := w * float64(p[3])
+					r += wa * float64(p[0])
+					g += wa * float64(p[1])
+					b += wa * float64(p[2])
+					a += wa
+					total += w
+				}
+			}
+
+			idx := 4 * (j*width + i)
+			if a > 0 {
+				pixels[idx] = byte(r/a + 0.5)
+				pixels[idx+1] = byte(g/a + 0.5)
+				pixels[idx+2] = byte(b/a + 0.5)
+			}
+			if total > 0 {
+				pixels[idx+3] = byte(a/total + 0.5)
+			}
 		}
 	}
-	return closest
+
+	return &Image{
+		Width:  width,
+		Height: height,
+		Pixels: pixels,
+	}
 }
 
 func createIcon(image *Image, xhot, yhot int, icon bool) (_HICON, error) {
@@ -1534,9 +1604,16 @@ func (w *Window) platformSetWindowTitle(title string) error {
 }
 
 func (w *Window) platformSetWindowIcon(images []*Image) error {
+	return w.setWindowIcons(images, images)
+}
+
+// setWindowIcons sets the small icon chosen from smallImages and the big icon chosen from bigImages.
+// If either is empty, setWindowIcons reverts the icons to the default ones.
+func (w *Window) setWindowIcons(smallImages, bigImages []*Image) error {
 	var bigIcon, smallIcon _HICON
 
-	if len(images) > 0 {
+	set := len(smallImages) > 0 && len(bigImages) > 0
+	if set {
 		cxIcon, err := _GetSystemMetrics(_SM_CXICON)
 		if err != nil {
 			return err
@@ -1554,8 +1631,8 @@ func (w *Window) platformSetWindowIcon(images []*Image) error {
 			return err
 		}
 
-		bigImage := chooseImage(images, int(cxIcon), int(cyIcon))
-		smallImage := chooseImage(images, int(cxsmIcon), int(cysmIcon))
+		bigImage := chooseImage(bigImages, int(cxIcon), int(cyIcon))
+		smallImage := chooseImage(smallImages, int(cxsmIcon), int(cysmIcon))
 
 		bigIcon, err = createIcon(bigImage, 0, 0, true)
 		if err != nil {
@@ -1593,7 +1670,7 @@ func (w *Window) platformSetWindowIcon(images []*Image) error {
 		}
 	}
 
-	if len(images) > 0 {
+	if set {
 		w.platform.bigIcon = bigIcon
 		w.platform.smallIcon = smallIcon
 	} else {
@@ -2345,6 +2422,20 @@ func platformGetKeyScancode(key Key) int {
 	return _glfw.platformWindow.scancodes[key]
 }
 
+func (c *Cursor) platformCreateCursor(image *Image, xhot, yhot int) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	h, err := createIcon(image, xhot, yhot, false)
+	if err != nil {
+		return err
+	}
+	c.platform.handle = _HCURSOR(h)
+
+	return nil
+}
+
 func (c *Cursor) platformCreateStandardCursor(shape StandardCursor) error {
 	if microsoftgdk.IsXbox() {
 		return nil
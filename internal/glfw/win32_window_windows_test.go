@@ -0,0 +1,137 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glfw
+
+import (
+	"testing"
+)
+
+func newTestImage(width, height int, r, g, b, a byte) *Image {
+	pix := make([]byte, 4*width*height)
+	for i := 0; i < len(pix); i += 4 {
+		pix[i] = r
+		pix[i+1] = g
+		pix[i+2] = b
+		pix[i+3] = a
+	}
+	return &Image{
+		Width:  width,
+		Height: height,
+		Pixels: pix,
+	}
+}
+
+func TestChooseImage(t *testing.T) {
+	img16 := newTestImage(16, 16, 0xff, 0, 0, 0xff)
+	img32 := newTestImage(32, 32, 0, 0xff, 0, 0xff)
+	img48 := newTestImage(48, 48, 0, 0, 0xff, 0xff)
+
+	testCases := []struct {
+		name   string
+		images []*Image
+		width  int
+		height int
+		want   *Image
+	}{
+		{
+			name:   "exact",
+			images: []*Image{img16, img32, img48},
+			width:  32,
+			height: 32,
+			want:   img32,
+		},
+		{
+			name:   "smallest bigger one",
+			images: []*Image{img48, img16, img32},
+			width:  24,
+			height: 24,
+			want:   img32,
+		},
+		{
+			name:   "largest if no bigger one",
+			images: []*Image{img16, img32},
+			width:  64,
+			height: 64,
+			want:   img32,
+		},
+		{
+			name:   "empty",
+			images: nil,
+			width:  32,
+			height: 32,
+			want:   nil,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := chooseImage(tc.images, tc.width, tc.height)
+			if tc.want == nil {
+				if got != nil {
+					t.Errorf("got: %dx%d, want: nil", got.Width, got.Height)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("got: nil, want: non-nil")
+			}
+			wantWidth, wantHeight := tc.width, tc.height
+			if tc.want.Width < tc.width || tc.want.Height < tc.height {
+				wantWidth, wantHeight = tc.want.Width, tc.want.Height
+			}
+			if got.Width != wantWidth || got.Height != wantHeight {
+				t.Errorf("size: got: %dx%d, want: %dx%d", got.Width, got.Height, wantWidth, wantHeight)
+			}
+			// The images have different colors, so the color tells which image is chosen.
+			if got, want := got.Pixels[:4], tc.want.Pixels[:4]; string(got) != string(want) {
+				t.Errorf("color: got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDownscaleImage(t *testing.T) {
+	// A 4x2 image whose left half is opaque red and right half is transparent.
+	img := newTestImage(4, 2, 0xff, 0, 0, 0xff)
+	for j := 0; j < 2; j++ {
+		for i := 2; i < 4; i++ {
+			copy(img.Pixels[4*(j*4+i):], []byte{0, 0xff, 0, 0})
+		}
+	}
+
+	got := downscaleImage(img, 2, 1)
+	if got.Width != 2 || got.Height != 1 {
+		t.Fatalf("size: got: %dx%d, want: 2x1", got.Width, got.Height)
+	}
+	want := []byte{
+		0xff, 0, 0, 0xff,
+		// The color of a transparent pixel is zero.
+		0, 0, 0, 0,
+	}
+	if string(got.Pixels) != string(want) {
+		t.Errorf("got: %v, want: %v", got.Pixels, want)
+	}
+
+	// Mixing an opaque pixel and a transparent pixel: the color is not darkened by the transparent pixel's color.
+	got = downscaleImage(img, 1, 1)
+	if want := []byte{0xff, 0, 0, 0x80}; string(got.Pixels) != string(want) {
+		t.Errorf("got: %v, want: %v", got.Pixels, want)
+	}
+
+	// An image not bigger than the size is returned as it is.
+	if got := downscaleImage(img, 8, 8); got != img {
+		t.Errorf("downscaleImage must return the image as it is when the image is smaller than the size")
+	}
+}
@@ -380,6 +380,13 @@ func (w *Window) SetIcon(images []image.Image) error {
 	return nil
 }
 
+// SetIconPair sets the small icon and the large icon explicitly.
+//
+// As a window manager chooses an icon from the given candidates on X11, this is the same as SetIcon with the two images.
+func (w *Window) SetIconPair(small, large image.Image) error {
+	return w.SetIcon([]image.Image{small, large})
+}
+
 // GetPos returns the position, in screen coordinates, of the upper-left
 // corner of the client area of the window.
 func (w *Window) GetPos() (x, y int, err error) {
@@ -520,8 +527,12 @@ func (w *Window) GetContentScale() (float32, float32) {
 // The initial opacity value for newly created windows is one.
 //
 // This function may only be called from the main thread.
-func (w *Window) GetOpacity() float32 {
-	return float32(C.glfwGetWindowOpacity(w.data))
+func (w *Window) GetOpacity() (float32, error) {
+	o := C.glfwGetWindowOpacity(w.data)
+	if err := fetchErrorIgnoringPlatformError(); err != nil {
+		return 0, err
+	}
+	return float32(o), nil
 }
 
 // SetOpacity function sets the opacity of the window, including any
@@ -534,8 +545,12 @@ func (w *Window) GetOpacity() float32 {
 // transparency. The results of doing this are undefined.
 //
 // This function may only be called from the main thread.
-func (w *Window) SetOpacity(opacity float32) {
+func (w *Window) SetOpacity(opacity float32) error {
 	C.glfwSetWindowOpacity(w.data, C.float(opacity))
+	if err := fetchErrorIgnoringPlatformError(); err != nil {
+		return err
+	}
+	return nil
 }
 
 // RequestAttention function requests user attention to the specified
@@ -362,14 +362,7 @@ func (w *Window) SetIcon(images []image.Image) error {
 
 	gimgs := make([]*Image, len(images))
 	for i, img := range images {
-		b := img.Bounds()
-		m := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
-		draw.Draw(m, m.Bounds(), img, b.Min, draw.Src)
-		gimgs[i] = &Image{
-			Width:  b.Dx(),
-			Height: b.Dy(),
-			Pixels: m.Pix,
-		}
+		gimgs[i] = toGLFWImage(img)
 	}
 
 	if err := w.platformSetWindowIcon(gimgs); err != nil {
@@ -378,6 +371,30 @@ func (w *Window) SetIcon(images []image.Image) error {
 	return nil
 }
 
+// SetIconPair sets the small icon for the title bar and the big icon for the taskbar and the task switcher explicitly.
+// The icons are scaled down to the sizes the system desires if needed.
+func (w *Window) SetIconPair(small, large image.Image) error {
+	if !_glfw.initialized {
+		return NotInitialized
+	}
+
+	if err := w.setWindowIcons([]*Image{toGLFWImage(small)}, []*Image{toGLFWImage(large)}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func toGLFWImage(img image.Image) *Image {
+	b := img.Bounds()
+	m := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(m, m.Bounds(), img, b.Min, draw.Src)
+	return &Image{
+		Width:  b.Dx(),
+		Height: b.Dy(),
+		Pixels: m.Pix,
+	}
+}
+
 func (w *Window) GetPos() (xpos, ypos int, err error) {
 	if !_glfw.initialized {
 		return 0, 0, NotInitialized
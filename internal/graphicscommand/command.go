@@ -143,33 +143,39 @@ func (c *drawTrianglesCommand) setVertices(vertices []float32) {
 // CanMergeWithDrawTrianglesCommand returns a boolean value indicating whether the other drawTrianglesCommand can be merged
 // with the drawTrianglesCommand c.
 func (c *drawTrianglesCommand) CanMergeWithDrawTrianglesCommand(dst *Image, srcs [graphics.ShaderImageCount]*Image, vertices []float32, blend graphicsdriver.Blend, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) bool {
+	return c.mergeBlocker(dst, srcs, vertices, blend, shader, uniforms, fillRule) == ""
+}
+
+// mergeBlocker returns a reason why the other drawTrianglesCommand cannot be merged with the drawTrianglesCommand c.
+// mergeBlocker returns an empty string if the command can be merged.
+func (c *drawTrianglesCommand) mergeBlocker(dst *Image, srcs [graphics.ShaderImageCount]*Image, vertices []float32, blend graphicsdriver.Blend, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) string {
 	if c.shader != shader {
-		return false
+		return "different shader"
 	}
 	if len(c.uniforms) != len(uniforms) {
-		return false
+		return "different uniforms"
 	}
 	for i := range c.uniforms {
 		if c.uniforms[i] != uniforms[i] {
-			return false
+			return "different uniforms"
 		}
 	}
 	if c.dst != dst {
-		return false
+		return "different destination image"
 	}
 	if c.srcs != srcs {
-		return false
+		return "different source images"
 	}
 	if c.blend != blend {
-		return false
+		return "different blend"
 	}
 	if c.fillRule != fillRule {
-		return false
+		return "different fill rule"
 	}
 	if c.fillRule != graphicsdriver.FillAll && mightOverlapDstRegions(c.vertices, vertices) {
-		return false
+		return "overlapping regions with a fill rule"
 	}
-	return true
+	return ""
 }
 
 var (
@@ -57,6 +57,36 @@ func SetVsyncEnabled(enabled bool, graphicsDriver graphicsdriver.Graphics) {
 	}, true)
 }
 
+var (
+	currentFrameDrawCallCount atomic.Int64
+	lastFrameDrawCallCount    atomic.Int64
+	batchScopeDepth           atomic.Int32
+)
+
+// LastFrameDrawCallCount returns the number of draw calls for the draw-triangles commands executed in the last frame.
+// A command with multiple destination regions is counted as multiple draw calls.
+func LastFrameDrawCallCount() int {
+	return int(lastFrameDrawCallCount.Load())
+}
+
+// CurrentFrameDrawCallCountForTesting returns the number of draw calls executed so far in the current frame.
+func CurrentFrameDrawCallCountForTesting() int {
+	return int(currentFrameDrawCallCount.Load())
+}
+
+// BeginBatchScope begins a scope where broken batches are logged in debug builds.
+func BeginBatchScope() {
+	batchScopeDepth.Add(1)
+}
+
+// EndBatchScope ends a scope begun by BeginBatchScope.
+func EndBatchScope() {
+	if batchScopeDepth.Add(-1) < 0 {
+		batchScopeDepth.Add(1)
+		panic("graphicscommand: EndBatchScope is called without BeginBatchScope")
+	}
+}
+
 // FlushCommands flushes the command queue and present the screen if needed.
 // If endFrame is true, the current screen might be used to present.
 func FlushCommands(graphicsDriver graphicsdriver.Graphics, endFrame bool) error {
@@ -133,7 +163,8 @@ func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, srcs [graphics.Sh
 	// TODO: If dst is the screen, reorder the command to be the last.
 	if !split && 0 < len(q.commands) {
 		if last, ok := q.commands[len(q.commands)-1].(*drawTrianglesCommand); ok {
-			if last.CanMergeWithDrawTrianglesCommand(dst, srcs, vertices, blend, shader, uniforms, fillRule) {
+			reason := last.mergeBlocker(dst, srcs, vertices, blend, shader, uniforms, fillRule)
+			if reason == "" {
 				last.setVertices(q.lastVertices(len(vertices) + last.numVertices()))
 				if last.dstRegions[len(last.dstRegions)-1].Region == dstRegion {
 					last.dstRegions[len(last.dstRegions)-1].IndexCount += len(indices)
@@ -145,6 +176,9 @@ func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, srcs [graphics.Sh
 				}
 				return
 			}
+			if debug.IsDebug && batchScopeDepth.Load() > 0 {
+				debug.Logf("Batch broken: %s\n", reason)
+			}
 		}
 	}
 
@@ -258,6 +292,7 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 		q.tmpNumVertexFloats = 0
 
 		if endFrame {
+			lastFrameDrawCallCount.Store(currentFrameDrawCallCount.Swap(0))
 			q.uint32sBuffer.reset()
 			for i, f := range q.finalizers {
 				f()
@@ -300,6 +335,8 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 			// introduced than drawTrianglesCommand.
 			if dtc, ok := c.(*drawTrianglesCommand); ok {
 				indexOffset += dtc.numIndices()
+				// A command issues one draw call for each destination region.
+				currentFrameDrawCallCount.Add(int64(len(dtc.dstRegions)))
 			}
 		}
 		cs = cs[nc:]
@@ -44,6 +44,7 @@ func RunBeforeUpdateHooks() error {
 
 var (
 	audioSuspended bool
+	audioPaused    bool
 	onSuspendAudio func() error
 	onResumeAudio  func() error
 )
@@ -60,26 +61,51 @@ func OnResumeAudio(f func() error) {
 	m.Unlock()
 }
 
+// SuspendAudio suspends audio due to the platform state, e.g. when the window is unfocused.
 func SuspendAudio() error {
 	m.Lock()
 	defer m.Unlock()
-	if audioSuspended {
-		return nil
-	}
-	audioSuspended = true
-	if onSuspendAudio != nil {
-		return onSuspendAudio()
-	}
-	return nil
+	return setAudioState(true, audioPaused)
 }
 
+// ResumeAudio resumes audio suspended by SuspendAudio.
+// Audio is not resumed while audio is paused by PauseAudio.
 func ResumeAudio() error {
 	m.Lock()
 	defer m.Unlock()
-	if !audioSuspended {
+	return setAudioState(false, audioPaused)
+}
+
+// PauseAudio suspends audio due to the game's request.
+// PauseAudio works independently from SuspendAudio.
+func PauseAudio() error {
+	m.Lock()
+	defer m.Unlock()
+	return setAudioState(audioSuspended, true)
+}
+
+// UnpauseAudio resumes audio paused by PauseAudio.
+// Audio is not resumed while audio is suspended by SuspendAudio.
+func UnpauseAudio() error {
+	m.Lock()
+	defer m.Unlock()
+	return setAudioState(audioSuspended, false)
+}
+
+func setAudioState(suspended, paused bool) error {
+	prev := audioSuspended || audioPaused
+	audioSuspended = suspended
+	audioPaused = paused
+	current := audioSuspended || audioPaused
+	if prev == current {
+		return nil
+	}
+	if current {
+		if onSuspendAudio != nil {
+			return onSuspendAudio()
+		}
 		return nil
 	}
-	audioSuspended = false
 	if onResumeAudio != nil {
 		return onResumeAudio()
 	}
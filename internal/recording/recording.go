@@ -0,0 +1,347 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recording provides encoders of animated images that encode frames one by one.
+//
+// As opposed to image/gif's EncodeAll, the encoders don't keep the frames,
+// and only the encoded data is kept in memory.
+package recording
+
+import (
+	"bytes"
+	"compress/lzw"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"io"
+)
+
+// Encoder encodes frames of an animated image.
+type Encoder interface {
+	// EncodeFrame encodes a frame.
+	//
+	// img must have the size given at the creation of the encoder, and have pre-multiplied alpha values.
+	// duration is the number of the frame intervals for which the frame is shown.
+	EncodeFrame(img *image.RGBA, duration int) error
+
+	// Finish finishes the encoding and returns the encoded stream.
+	Finish() (io.Reader, error)
+}
+
+func checkFrame(img *image.RGBA, width, height int, duration int) error {
+	if img.Bounds().Dx() != width || img.Bounds().Dy() != height {
+		return fmt.Errorf("recording: the frame size must be (%d, %d) but (%d, %d)", width, height, img.Bounds().Dx(), img.Bounds().Dy())
+	}
+	if duration <= 0 {
+		return fmt.Errorf("recording: duration must be positive but %d", duration)
+	}
+	return nil
+}
+
+// gifPaletteSize is the number of the colors in the 6x6x6 color cube used for GIF.
+const gifPaletteSize = 6 * 6 * 6
+
+// bayer4 is a 4x4 Bayer matrix for ordered dithering.
+var bayer4 = [16]int{
+	0, 8, 2, 10,
+	12, 4, 14, 6,
+	3, 11, 1, 9,
+	15, 7, 13, 5,
+}
+
+type gifEncoder struct {
+	buf     bytes.Buffer
+	width   int
+	height  int
+	fps     int
+	elapsed int
+	indices []byte
+}
+
+// NewGIFEncoder creates a new Encoder for an animated GIF that loops forever.
+//
+// fps is the number of the frame intervals per second.
+// The colors are reduced to a fixed palette with ordered dithering, and the alpha values are ignored.
+func NewGIFEncoder(width, height int, fps int) (Encoder, error) {
+	if width <= 0 || width > 0xffff || height <= 0 || height > 0xffff {
+		return nil, fmt.Errorf("recording: invalid size for GIF: (%d, %d)", width, height)
+	}
+	if fps <= 0 {
+		return nil, fmt.Errorf("recording: fps must be positive but %d", fps)
+	}
+
+	e := &gifEncoder{
+		width:   width,
+		height:  height,
+		fps:     fps,
+		indices: make([]byte, width*height),
+	}
+
+	e.buf.WriteString("GIF89a")
+
+	// The logical screen descriptor with a global color table of 256 colors.
+	_ = binary.Write(&e.buf, binary.LittleEndian, [2]uint16{uint16(width), uint16(height)})
+	e.buf.Write([]byte{0xf7, 0x00, 0x00})
+	for i := 0; i < 256; i++ {
+		if i >= gifPaletteSize {
+			e.buf.Write([]byte{0, 0, 0})
+			continue
+		}
+		e.buf.Write([]byte{byte(i / 36 * 51), byte(i / 6 % 6 * 51), byte(i % 6 * 51)})
+	}
+
+	// The application extension to loop the animation forever.
+	e.buf.Write([]byte{0x21, 0xff, 0x0b})
+	e.buf.WriteString("NETSCAPE2.0")
+	e.buf.Write([]byte{0x03, 0x01, 0x00, 0x00, 0x00})
+
+	return e, nil
+}
+
+func (e *gifEncoder) EncodeFrame(img *image.RGBA, duration int) error {
+	if err := checkFrame(img, e.width, e.height, duration); err != nil {
+		return err
+	}
+
+	// The delay is in 1/100 seconds. Calculate it from the total time to avoid accumulating rounding errors.
+	delay := (200*(e.elapsed+duration)+e.fps)/(2*e.fps) - (200*e.elapsed+e.fps)/(2*e.fps)
+	e.elapsed += duration
+	if delay > 0xffff {
+		delay = 0xffff
+	}
+
+	// The graphic control extension. The disposal method is 'do not dispose'.
+	e.buf.Write([]byte{0x21, 0xf9, 0x04, 0x04})
+	_ = binary.Write(&e.buf, binary.LittleEndian, uint16(delay))
+	e.buf.Write([]byte{0x00, 0x00})
+
+	// The image descriptor.
+	e.buf.WriteByte(0x2c)
+	_ = binary.Write(&e.buf, binary.LittleEndian, [4]uint16{0, 0, uint16(e.width), uint16(e.height)})
+	e.buf.WriteByte(0x00)
+
+	for j := 0; j < e.height; j++ {
+		row := img.Pix[j*img.Stride : j*img.Stride+4*e.width]
+		for i := 0; i < e.width; i++ {
+			// Quantize each channel into 6 levels with a threshold in [0, 1) from the Bayer matrix.
+			t := (2*bayer4[(j%4)*4+i%4] + 1) * 255
+			r := (int(row[4*i])*160 + t) / (255 * 32)
+			g := (int(row[4*i+1])*160 + t) / (255 * 32)
+			b := (int(row[4*i+2])*160 + t) / (255 * 32)
+			e.indices[j*e.width+i] = byte(r*36 + g*6 + b)
+		}
+	}
+
+	const litWidth = 8
+	e.buf.WriteByte(litWidth)
+	bw := &blockWriter{w: &e.buf}
+	lw := lzw.NewWriter(bw, lzw.LSB, litWidth)
+	if _, err := lw.Write(e.indices); err != nil {
+		return err
+	}
+	if err := lw.Close(); err != nil {
+		return err
+	}
+	bw.close()
+
+	return nil
+}
+
+func (e *gifEncoder) Finish() (io.Reader, error) {
+	e.buf.WriteByte(0x3b)
+	return &e.buf, nil
+}
+
+// blockWriter splits data into GIF's sub-blocks, each of which has 255 bytes at most.
+type blockWriter struct {
+	w   *bytes.Buffer
+	buf [255]byte
+	n   int
+}
+
+func (b *blockWriter) Write(p []byte) (int, error) {
+	total := len(p)
+	for len(p) > 0 {
+		n := copy(b.buf[b.n:], p)
+		b.n += n
+		p = p[n:]
+		if b.n == len(b.buf) {
+			b.flush()
+		}
+	}
+	return total, nil
+}
+
+func (b *blockWriter) flush() {
+	if b.n == 0 {
+		return
+	}
+	b.w.WriteByte(byte(b.n))
+	b.w.Write(b.buf[:b.n])
+	b.n = 0
+}
+
+func (b *blockWriter) close() {
+	b.flush()
+	// The block terminator.
+	b.w.WriteByte(0x00)
+}
+
+type apngEncoder struct {
+	buf        bytes.Buffer
+	width      int
+	height     int
+	fps        int
+	frameCount int
+	sequence   uint32
+
+	zbuf    bytes.Buffer
+	zw      *zlib.Writer
+	current []byte
+	prev    []byte
+	chunk   []byte
+}
+
+// NewAPNGEncoder creates a new Encoder for an animated PNG that loops forever.
+//
+// fps is the number of the frame intervals per second.
+func NewAPNGEncoder(width, height int, fps int) (Encoder, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("recording: invalid size for APNG: (%d, %d)", width, height)
+	}
+	if fps <= 0 || fps > 0xffff {
+		return nil, fmt.Errorf("recording: invalid fps for APNG: %d", fps)
+	}
+	e := &apngEncoder{
+		width:   width,
+		height:  height,
+		fps:     fps,
+		current: make([]byte, 1+4*width),
+		prev:    make([]byte, 1+4*width),
+	}
+	e.zw = zlib.NewWriter(&e.zbuf)
+	return e, nil
+}
+
+func (e *apngEncoder) EncodeFrame(img *image.RGBA, duration int) error {
+	if err := checkFrame(img, e.width, e.height, duration); err != nil {
+		return err
+	}
+	if duration > 0xffff {
+		duration = 0xffff
+	}
+
+	// The frame control chunk.
+	var fctl [26]byte
+	binary.BigEndian.PutUint32(fctl[0:4], e.sequence)
+	binary.BigEndian.PutUint32(fctl[4:8], uint32(e.width))
+	binary.BigEndian.PutUint32(fctl[8:12], uint32(e.height))
+	binary.BigEndian.PutUint16(fctl[20:22], uint16(duration))
+	binary.BigEndian.PutUint16(fctl[22:24], uint16(e.fps))
+	// The offsets, dispose_op and blend_op are zeros.
+	e.sequence++
+	e.writeChunk("fcTL", fctl[:])
+
+	e.zbuf.Reset()
+	e.zw.Reset(&e.zbuf)
+	for i := range e.prev {
+		e.prev[i] = 0
+	}
+	for j := 0; j < e.height; j++ {
+		row := img.Pix[j*img.Stride : j*img.Stride+4*e.width]
+		// Un-premultiply the alpha values.
+		for i := 0; i < e.width; i++ {
+			r, g, b, a := int(row[4*i]), int(row[4*i+1]), int(row[4*i+2]), int(row[4*i+3])
+			if a != 0 && a != 0xff {
+				r = (r*0xff + a/2) / a
+				g = (g*0xff + a/2) / a
+				b = (b*0xff + a/2) / a
+			}
+			e.current[1+4*i] = byte(r)
+			e.current[1+4*i+1] = byte(g)
+			e.current[1+4*i+2] = byte(b)
+			e.current[1+4*i+3] = byte(a)
+		}
+
+		// Use the Up filter, which works well for game screens with few changes between the rows.
+		// The raw row is kept in prev for the next row.
+		e.current[0] = 2
+		for i := 1; i < len(e.current); i++ {
+			e.current[i], e.prev[i] = e.current[i]-e.prev[i], e.current[i]
+		}
+		if _, err := e.zw.Write(e.current); err != nil {
+			return err
+		}
+	}
+	if err := e.zw.Close(); err != nil {
+		return err
+	}
+
+	if e.frameCount == 0 {
+		e.writeChunk("IDAT", e.zbuf.Bytes())
+	} else {
+		e.chunk = e.chunk[:0]
+		e.chunk = binary.BigEndian.AppendUint32(e.chunk, e.sequence)
+		e.chunk = append(e.chunk, e.zbuf.Bytes()...)
+		e.sequence++
+		e.writeChunk("fdAT", e.chunk)
+	}
+	e.frameCount++
+
+	return nil
+}
+
+func (e *apngEncoder) writeChunk(name string, data []byte) {
+	writeChunk(&e.buf, name, data)
+}
+
+func (e *apngEncoder) Finish() (io.Reader, error) {
+	if e.frameCount == 0 {
+		return nil, errors.New("recording: APNG requires at least one frame")
+	}
+
+	var header bytes.Buffer
+	header.WriteString("\x89PNG\r\n\x1a\n")
+
+	var ihdr [13]byte
+	binary.BigEndian.PutUint32(ihdr[0:4], uint32(e.width))
+	binary.BigEndian.PutUint32(ihdr[4:8], uint32(e.height))
+	// The bit depth is 8, and the color type is RGBA.
+	ihdr[8] = 8
+	ihdr[9] = 6
+	writeChunk(&header, "IHDR", ihdr[:])
+
+	// The animation control chunk. The number of plays is zero, which means infinite.
+	var actl [8]byte
+	binary.BigEndian.PutUint32(actl[0:4], uint32(e.frameCount))
+	writeChunk(&header, "acTL", actl[:])
+
+	var footer bytes.Buffer
+	writeChunk(&footer, "IEND", nil)
+
+	return io.MultiReader(&header, &e.buf, &footer), nil
+}
+
+func writeChunk(buf *bytes.Buffer, name string, data []byte) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	buf.WriteString(name)
+	buf.Write(data)
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write([]byte(name))
+	_, _ = crc.Write(data)
+	_ = binary.Write(buf, binary.BigEndian, crc.Sum32())
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/recording"
+)
+
+func newFrame(width, height int, clr color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i] = clr.R
+		img.Pix[i+1] = clr.G
+		img.Pix[i+2] = clr.B
+		img.Pix[i+3] = clr.A
+	}
+	return img
+}
+
+func TestGIFEncoder(t *testing.T) {
+	const (
+		w   = 300
+		h   = 200
+		fps = 30
+	)
+	e, err := recording.NewGIFEncoder(w, h, fps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The colors are in the palette, so they must be kept as they are.
+	colors := []color.RGBA{
+		{0xff, 0, 0, 0xff},
+		{0, 0x66, 0xcc, 0xff},
+		{0x33, 0x33, 0x33, 0xff},
+	}
+	durations := []int{1, 1, 2}
+	for i, clr := range colors {
+		if err := e.EncodeFrame(newFrame(w, h, clr), durations[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := e.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(g.Image), len(colors); got != want {
+		t.Fatalf("len(g.Image): got: %d, want: %d", got, want)
+	}
+	if g.LoopCount != 0 {
+		t.Errorf("g.LoopCount: got: %d, want: 0", g.LoopCount)
+	}
+	// 1/30 [s] is not an integer in 1/100 [s], but the sum must be correct.
+	if got, want := g.Delay[0]+g.Delay[1]+g.Delay[2], 13; got != want {
+		t.Errorf("the sum of g.Delay: got: %d, want: %d", got, want)
+	}
+	for i, img := range g.Image {
+		if got, want := img.Bounds(), image.Rect(0, 0, w, h); got != want {
+			t.Errorf("g.Image[%d].Bounds(): got: %v, want: %v", i, got, want)
+		}
+		got := color.RGBAModel.Convert(img.At(w/2, h/2)).(color.RGBA)
+		if want := colors[i]; got != want {
+			t.Errorf("g.Image[%d].At(%d, %d): got: %v, want: %v", i, w/2, h/2, got, want)
+		}
+	}
+}
+
+func TestAPNGEncoder(t *testing.T) {
+	const (
+		w   = 30
+		h   = 20
+		fps = 60
+	)
+	e, err := recording.NewAPNGEncoder(w, h, fps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.EncodeFrame(newFrame(w, h, color.RGBA{0x40, 0x20, 0, 0x80}), 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.EncodeFrame(newFrame(w, h, color.RGBA{0, 0xff, 0, 0xff}), 3); err != nil {
+		t.Fatal(err)
+	}
+	r, err := e.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A decoder not supporting APNG must read the first frame.
+	img, err := png.Decode(bytes.NewReader(bs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := color.NRGBAModel.Convert(img.At(w/2, h/2)).(color.NRGBA)
+	if want := (color.NRGBA{0x80, 0x40, 0, 0x80}); got != want {
+		t.Errorf("img.At(%d, %d): got: %v, want: %v", w/2, h/2, got, want)
+	}
+
+	// Check the chunks.
+	var names []string
+	var sequences []uint32
+	var delays []uint16
+	for p := bs[8:]; len(p) > 0; {
+		n := binary.BigEndian.Uint32(p[0:4])
+		name := string(p[4:8])
+		data := p[8 : 8+n]
+		names = append(names, name)
+		switch name {
+		case "acTL":
+			if got, want := binary.BigEndian.Uint32(data[0:4]), uint32(2); got != want {
+				t.Errorf("the number of frames: got: %d, want: %d", got, want)
+			}
+		case "fcTL":
+			sequences = append(sequences, binary.BigEndian.Uint32(data[0:4]))
+			delays = append(delays, binary.BigEndian.Uint16(data[20:22]))
+			if got, want := binary.BigEndian.Uint16(data[22:24]), uint16(fps); got != want {
+				t.Errorf("delay_den: got: %d, want: %d", got, want)
+			}
+		case "fdAT":
+			sequences = append(sequences, binary.BigEndian.Uint32(data[0:4]))
+		}
+		p = p[12+n:]
+	}
+	if got, want := names, []string{"IHDR", "acTL", "fcTL", "IDAT", "fcTL", "fdAT", "IEND"}; !equalStrings(got, want) {
+		t.Errorf("chunks: got: %v, want: %v", got, want)
+	}
+	for i, s := range sequences {
+		if s != uint32(i) {
+			t.Errorf("sequences: got: %v, want: consecutive numbers from 0", sequences)
+			break
+		}
+	}
+	if len(delays) != 2 || delays[0] != 1 || delays[1] != 3 {
+		t.Errorf("delays: got: %v, want: [1 3]", delays)
+	}
+}
+
+func TestEncoderInvalidFrame(t *testing.T) {
+	e, err := recording.NewGIFEncoder(10, 10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.EncodeFrame(newFrame(20, 10, color.RGBA{}), 1); err == nil {
+		t.Errorf("EncodeFrame with a different size must return an error")
+	}
+	if err := e.EncodeFrame(newFrame(10, 10, color.RGBA{}), 0); err == nil {
+		t.Errorf("EncodeFrame with a zero duration must return an error")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -36,8 +36,9 @@ type Game interface {
 	Layout(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
 	UpdateInputState(fn func(*InputState))
 	Update() error
-	DrawOffscreen() error
+	DrawOffscreen(screenScale, screenOffsetX, screenOffsetY float64) error
 	DrawFinalScreen(scale, offsetX, offsetY float64)
+	DeviceScaleFactorChanged(deviceScaleFactor float64)
 }
 
 type context struct {
@@ -48,10 +49,11 @@ type context struct {
 	offscreen *Image
 	screen    *Image
 
-	screenWidth     float64
-	screenHeight    float64
-	offscreenWidth  float64
-	offscreenHeight float64
+	screenWidth       float64
+	screenHeight      float64
+	offscreenWidth    float64
+	offscreenHeight   float64
+	deviceScaleFactor float64
 
 	isOffscreenModified bool
 	lastDrawTime        time.Time
@@ -123,6 +125,15 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 		return nil
 	}
 
+	// Notify the change of the device scale factor, e.g. when the window is moved to another monitor.
+	// The initial value is not regarded as a change.
+	if c.deviceScaleFactor != deviceScaleFactor {
+		if c.deviceScaleFactor != 0 {
+			c.game.DeviceScaleFactorChanged(deviceScaleFactor)
+		}
+		c.deviceScaleFactor = deviceScaleFactor
+	}
+
 	// Update the input state after the layout is updated as a cursor position is affected by the layout.
 	if err := ui.updateInputState(); err != nil {
 		return err
@@ -131,6 +142,21 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 	// Ensure that Update is called once before Draw so that Update can be used for initialization.
 	if !c.updateCalled && updateCount == 0 {
 		updateCount = 1
+	}
+
+	// While Update is paused, the ticks are discarded and are not caught up after resuming.
+	// The input state is still updated once per frame so that the game can detect resuming in Draw.
+	// The game keeps the files dropped during the pause and handles them at the first Update after resuming.
+	if c.updateCalled && ui.IsUpdatePaused() {
+		updateCount = 0
+		c.game.UpdateInputState(func(inputState *InputState) {
+			ui.readInputState(inputState)
+		})
+		if err := hook.RunBeforeUpdateHooks(); err != nil {
+			return err
+		}
+	}
+	if updateCount > 0 {
 		c.updateCalled = true
 	}
 	debug.Logf("Update count per frame: %d\n", updateCount)
@@ -161,6 +187,11 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 		return err
 	}
 
+	// Update the custom cursor for the same reason as icons.
+	if err := ui.updateCustomCursorIfNeeded(); err != nil {
+		return err
+	}
+
 	// Draw the game.
 	if err := c.drawGame(graphicsDriver, ui, forceDraw); err != nil {
 		return err
@@ -194,7 +225,8 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 		c.offscreen.clear()
 	}
 
-	if err := c.game.DrawOffscreen(); err != nil {
+	// The arguments are the same as DrawFinalScreen's in this frame, so that the final screen can be reproduced in Draw.
+	if err := c.game.DrawOffscreen(c.screenScaleAndOffsets()); err != nil {
 		return err
 	}
 
@@ -0,0 +1,92 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"image"
+	"time"
+)
+
+// customCursor represents frames of a custom cursor set by SetCustomCursor.
+type customCursor struct {
+	frames  []image.Image
+	hotspot image.Point
+	fps     int
+}
+
+func newCustomCursor(frames []image.Image, hotspot image.Point, fps int) *customCursor {
+	if len(frames) == 0 {
+		return nil
+	}
+	c := &customCursor{
+		frames:  make([]image.Image, len(frames)),
+		hotspot: hotspot,
+		fps:     fps,
+	}
+	copy(c.frames, frames)
+	return c
+}
+
+// rgbaFrames returns the frames converted to *image.RGBA.
+//
+// rgbaFrames must be called during a frame, since a frame might be *ebiten.Image and
+// getting pixels from it needs to be in a frame (#1468).
+func (c *customCursor) rgbaFrames() []*image.RGBA {
+	imgs := make([]*image.RGBA, len(c.frames))
+	for i, img := range c.frames {
+		// TODO: If img is not *ebiten.Image, this converting is not necessary.
+		// However, this package cannot refer *ebiten.Image due to the package
+		// dependencies.
+
+		b := img.Bounds()
+		rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+		for j := b.Min.Y; j < b.Max.Y; j++ {
+			for i := b.Min.X; i < b.Max.X; i++ {
+				rgba.Set(i-b.Min.X, j-b.Min.Y, img.At(i, j))
+			}
+		}
+		imgs[i] = rgba
+	}
+	return imgs
+}
+
+// customCursorAnimation decides the frame of a custom cursor to show at the current time.
+type customCursorAnimation struct {
+	frameCount int
+	fps        int
+	start      time.Time
+	index      int
+}
+
+// reset resets the animation. The first frame is regarded as already shown.
+func (a *customCursorAnimation) reset(frameCount int, fps int) {
+	a.frameCount = frameCount
+	a.fps = fps
+	a.start = time.Now()
+	a.index = 0
+}
+
+// update returns the index of the frame to show, and whether the index is changed from the last call.
+func (a *customCursorAnimation) update() (int, bool) {
+	if a.frameCount <= 1 || a.fps <= 0 {
+		return a.index, false
+	}
+	idx := int(time.Since(a.start).Seconds()*float64(a.fps)) % a.frameCount
+	if idx == a.index {
+		return idx, false
+	}
+	a.index = idx
+	return idx, true
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"sync"
+	"time"
+)
+
+// frameLimiter caps the number of frames per second.
+type frameLimiter struct {
+	fps  int
+	next time.Time
+
+	m sync.Mutex
+}
+
+func (f *frameLimiter) targetFPS() int {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return f.fps
+}
+
+func (f *frameLimiter) setTargetFPS(fps int) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if fps < 0 {
+		fps = 0
+	}
+	f.fps = fps
+	f.next = time.Time{}
+}
+
+// reserveFrame reserves a next frame and returns the duration to wait before the frame starts.
+func (f *frameLimiter) reserveFrame(now time.Time) time.Duration {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if f.fps == 0 {
+		return 0
+	}
+
+	interval := time.Second / time.Duration(f.fps)
+
+	// If the frames are too late, give up catching up.
+	if f.next.IsZero() || now.Sub(f.next) >= interval {
+		f.next = now
+	}
+	d := f.next.Sub(now)
+	f.next = f.next.Add(interval)
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// waitForNextFrame waits until a next frame can start.
+func (f *frameLimiter) waitForNextFrame() {
+	if d := f.reserveFrame(time.Now()); d > 0 {
+		time.Sleep(d)
+	}
+}
@@ -33,9 +33,12 @@ const (
 type TouchID int
 
 type Touch struct {
-	ID TouchID
-	X  int
-	Y  int
+	ID      TouchID
+	X       int
+	Y       int
+	Force   float64
+	RadiusX float64
+	RadiusY float64
 }
 
 type InputState struct {
@@ -38,9 +38,12 @@ var (
 )
 
 type touchInClient struct {
-	id TouchID
-	x  float64
-	y  float64
+	id      TouchID
+	x       float64
+	y       float64
+	force   float64
+	radiusX float64
+	radiusY float64
 }
 
 func jsCodeToID(code js.Value) Key {
@@ -145,12 +148,20 @@ func (u *UserInterface) updateInputFromEvent(e js.Value) error {
 	// overhead (#1437).
 	switch t := e.Get("type"); {
 	case t.Equal(stringKeydown):
-		if str := e.Get("key").String(); isKeyString(str) {
+		str := e.Get("key").String()
+		if isKeyString(str) {
 			for _, r := range str {
 				u.inputState.appendRune(r)
 			}
 		}
 		u.keyDown(e)
+
+		// Learn the key name from the 'key' property for browsers without Keyboard.getLayoutMap.
+		// A Key is a physical key from the 'code' property, and the 'key' property is the character for the current layout.
+		if id := jsCodeToID(e.Get("code")); id >= 0 {
+			modified := e.Get("shiftKey").Bool() || e.Get("ctrlKey").Bool() || e.Get("altKey").Bool() || e.Get("metaKey").Bool()
+			u.keyNames.learn(id, str, modified)
+		}
 	case t.Equal(stringKeyup):
 		u.keyUp(e)
 	case t.Equal(stringMousedown):
@@ -203,13 +214,25 @@ func (u *UserInterface) updateTouchesFromEvent(e js.Value) {
 	for i := 0; i < touches.Length(); i++ {
 		t := touches.Call("item", i)
 		u.touchesInClient = append(u.touchesInClient, touchInClient{
-			id: TouchID(t.Get("identifier").Int()),
-			x:  t.Get("clientX").Float(),
-			y:  t.Get("clientY").Float(),
+			id:      TouchID(t.Get("identifier").Int()),
+			x:       t.Get("clientX").Float(),
+			y:       t.Get("clientY").Float(),
+			force:   touchPropertyFloat(t, "force"),
+			radiusX: touchPropertyFloat(t, "radiusX"),
+			radiusY: touchPropertyFloat(t, "radiusY"),
 		})
 	}
 }
 
+// touchPropertyFloat returns the float value of the property of the touch, or 0 if the browser doesn't support the property.
+func touchPropertyFloat(t js.Value, name string) float64 {
+	v := t.Get(name)
+	if v.Type() != js.TypeNumber {
+		return 0
+	}
+	return v.Float()
+}
+
 func isKeyString(str string) bool {
 	// From https://www.w3.org/TR/uievents-key/#keys-unicode,
 	//
@@ -265,8 +288,10 @@ func (u *UserInterface) KeyName(key Key) string {
 
 	// keyboardLayoutMap is reset every tick.
 	if u.keyboardLayoutMap.IsUndefined() {
+		// Keyboard.getLayoutMap is not available on some browsers like Firefox and Safari.
+		// Use the key names learned from key events instead.
 		if !jsKeyboard.Truthy() {
-			return ""
+			return u.keyNames.name(key)
 		}
 
 		// Invoke getLayoutMap every tick to detect the keyboard change.
@@ -277,7 +302,7 @@ func (u *UserInterface) KeyName(key Key) string {
 
 	n := u.keyboardLayoutMap.Call("get", uiKeyToJSCode[key])
 	if n.IsUndefined() {
-		return ""
+		return u.keyNames.name(key)
 	}
 	return n.String()
 }
@@ -344,10 +369,15 @@ func (u *UserInterface) updateInputState() error {
 	u.inputState.Touches = u.inputState.Touches[:0]
 	for _, t := range u.touchesInClient {
 		x, y := u.context.clientPositionToLogicalPosition(t.x, t.y, s)
+		// Convert the radii in the same way as the position, as the client and logical coordinates have different scales.
+		rx, ry := u.context.clientPositionToLogicalPosition(t.x+t.radiusX, t.y+t.radiusY, s)
 		u.inputState.Touches = append(u.inputState.Touches, Touch{
-			ID: t.id,
-			X:  int(x),
-			Y:  int(y),
+			ID:      t.id,
+			X:       int(x),
+			Y:       int(y),
+			Force:   t.force,
+			RadiusX: rx - x,
+			RadiusY: ry - y,
 		})
 	}
 
@@ -0,0 +1,67 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// keyNames records names of physical keys for the current keyboard layout.
+//
+// A name is learned from a character that a key inputs, like KeyboardEvent.key in browsers.
+// keyNames is used when the platform doesn't provide a way to get a key name directly.
+type keyNames struct {
+	names map[Key]string
+}
+
+// learn learns the name of the key from the character str that the key inputs.
+// modified indicates whether a modifier key like Shift is pressed with the key.
+//
+// A character with a modifier key is ignored, as the modifier key changes the character
+// e.g. Shift and 1 input '!' on a US keyboard.
+func (k *keyNames) learn(key Key, str string, modified bool) {
+	if modified {
+		return
+	}
+	name, ok := keyNameFromCharacter(str)
+	if !ok {
+		return
+	}
+	if k.names == nil {
+		k.names = map[Key]string{}
+	}
+	k.names[key] = name
+}
+
+// name returns the learned name of the key. name returns an empty string if the name is not learned yet.
+func (k *keyNames) name(key Key) string {
+	return k.names[key]
+}
+
+// keyNameFromCharacter returns a key name from a character that a key inputs without modifier keys.
+// keyNameFromCharacter returns false if the character cannot be a key name, e.g. a named key like "Enter",
+// a dead key, or a white space.
+func keyNameFromCharacter(str string) (string, bool) {
+	r, size := utf8.DecodeRuneInString(str)
+	if r == utf8.RuneError || size != len(str) {
+		return "", false
+	}
+	if !unicode.IsGraphic(r) || unicode.IsSpace(r) {
+		return "", false
+	}
+	// A letter is capitalized when CapsLock is on. Use the lower case as Keyboard.getLayoutMap does.
+	return string(unicode.ToLower(r)), true
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"testing"
+)
+
+type keyEvent struct {
+	key      Key
+	str      string
+	modified bool
+}
+
+func TestKeyNames(t *testing.T) {
+	testCases := []struct {
+		layout string
+		events []keyEvent
+		want   map[Key]string
+	}{
+		{
+			layout: "QWERTY",
+			events: []keyEvent{
+				{key: KeyQ, str: "q"},
+				{key: KeyW, str: "w"},
+				{key: KeyDigit1, str: "1"},
+				{key: KeyDigit2, str: "@", modified: true},
+				{key: KeyEnter, str: "Enter"},
+				{key: KeySpace, str: " "},
+			},
+			want: map[Key]string{
+				KeyQ:      "q",
+				KeyW:      "w",
+				KeyDigit1: "1",
+				KeyDigit2: "",
+				KeyEnter:  "",
+				KeySpace:  "",
+			},
+		},
+		{
+			layout: "AZERTY",
+			events: []keyEvent{
+				{key: KeyQ, str: "a"},
+				{key: KeyW, str: "z"},
+				{key: KeyA, str: "q"},
+				{key: KeyDigit1, str: "&"},
+				{key: KeyDigit2, str: "é"},
+				{key: KeyBracketLeft, str: "Dead"},
+			},
+			want: map[Key]string{
+				KeyQ:           "a",
+				KeyW:           "z",
+				KeyA:           "q",
+				KeyDigit1:      "&",
+				KeyDigit2:      "é",
+				KeyBracketLeft: "",
+			},
+		},
+		{
+			layout: "QWERTZ with CapsLock",
+			events: []keyEvent{
+				{key: KeyY, str: "Z"},
+				{key: KeyZ, str: "Y"},
+				{key: KeySemicolon, str: "Ö"},
+			},
+			want: map[Key]string{
+				KeyY:         "z",
+				KeyZ:         "y",
+				KeySemicolon: "ö",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.layout, func(t *testing.T) {
+			var k keyNames
+			for _, e := range tc.events {
+				k.learn(e.key, e.str, e.modified)
+			}
+			for key, want := range tc.want {
+				if got := k.name(key); got != want {
+					t.Errorf("name(%s): got: %q, want: %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyNamesLayoutChange(t *testing.T) {
+	var k keyNames
+	k.learn(KeyQ, "q", false)
+	if got, want := k.name(KeyQ), "q"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// The layout is changed from QWERTY to AZERTY.
+	k.learn(KeyQ, "a", false)
+	if got, want := k.name(KeyQ), "a"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
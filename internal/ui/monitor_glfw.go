@@ -26,8 +26,9 @@ import (
 
 // Monitor is a wrapper around glfw.Monitor.
 type Monitor struct {
-	m         *glfw.Monitor
-	videoMode *glfw.VidMode
+	m          *glfw.Monitor
+	videoMode  *glfw.VidMode
+	videoModes []VideoMode
 
 	id                 int
 	name               string
@@ -51,6 +52,20 @@ func (m *Monitor) Size() (int, int) {
 	return int(w), int(h)
 }
 
+// RefreshRate returns the refresh rate of the monitor's current video mode in Hz.
+// RefreshRate returns 0 if the refresh rate is unknown.
+func (m *Monitor) RefreshRate() int {
+	if m.videoMode == nil {
+		return 0
+	}
+	return m.videoMode.RefreshRate
+}
+
+// AppendVideoModes appends the video modes supported by the monitor.
+func (m *Monitor) AppendVideoModes(modes []VideoMode) []VideoMode {
+	return append(modes, m.videoModes...)
+}
+
 func (m *Monitor) sizeInDIP() (float64, float64) {
 	w, h := m.boundsInGLFWPixels.Dx(), m.boundsInGLFWPixels.Dy()
 	s := m.DeviceScaleFactor()
@@ -149,6 +164,18 @@ func (m *monitors) update() error {
 		if err != nil {
 			return err
 		}
+		glfwVideoModes, err := m.GetVideoModes()
+		if err != nil {
+			return err
+		}
+		videoModes := make([]VideoMode, 0, len(glfwVideoModes))
+		for _, v := range glfwVideoModes {
+			videoModes = append(videoModes, VideoMode{
+				Width:       v.Width,
+				Height:      v.Height,
+				RefreshRate: v.RefreshRate,
+			})
+		}
 		name, err := m.GetName()
 		if err != nil {
 			return err
@@ -161,6 +188,7 @@ func (m *monitors) update() error {
 		newMonitors = append(newMonitors, &Monitor{
 			m:                  m,
 			videoMode:          videoMode,
+			videoModes:         videoModes,
 			id:                 i,
 			name:               name,
 			boundsInGLFWPixels: b,
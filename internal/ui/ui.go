@@ -23,6 +23,7 @@ import (
 	_ "github.com/ebitengine/hideconsole"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
 	"github.com/hajimehoshi/ebiten/v2/internal/mipmap"
 	"github.com/hajimehoshi/ebiten/v2/internal/thread"
 )
@@ -71,6 +72,14 @@ const (
 	WindowResizingModeEnabled
 )
 
+// VideoMode represents a video mode of a monitor.
+// Width and Height are in device pixels.
+type VideoMode struct {
+	Width       int
+	Height      int
+	RefreshRate int
+}
+
 type UserInterface struct {
 	err  error
 	errM sync.Mutex
@@ -79,6 +88,9 @@ type UserInterface struct {
 	graphicsLibrary           atomic.Int32
 	running                   atomic.Bool
 	terminated                atomic.Bool
+	updatePaused              atomic.Bool
+
+	frameLimiter frameLimiter
 
 	whiteImage *Image
 
@@ -214,6 +226,33 @@ func (u *UserInterface) GraphicsLibrary() GraphicsLibrary {
 	return GraphicsLibrary(u.graphicsLibrary.Load())
 }
 
+func (u *UserInterface) TargetFPS() int {
+	return u.frameLimiter.targetFPS()
+}
+
+func (u *UserInterface) SetTargetFPS(fps int) {
+	u.frameLimiter.setTargetFPS(fps)
+}
+
+func (u *UserInterface) IsUpdatePaused() bool {
+	return u.updatePaused.Load()
+}
+
+func (u *UserInterface) SetUpdatePaused(paused bool) {
+	if u.updatePaused.Swap(paused) == paused {
+		return
+	}
+	if paused {
+		if err := hook.PauseAudio(); err != nil {
+			u.setError(err)
+		}
+		return
+	}
+	if err := hook.UnpauseAudio(); err != nil {
+		u.setError(err)
+	}
+}
+
 func (u *UserInterface) isRunning() bool {
 	return u.running.Load() && !u.isTerminated()
 }
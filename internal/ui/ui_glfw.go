@@ -63,7 +63,10 @@ type userInterfaceImpl struct {
 	runnableOnUnfocused  bool
 	fpsMode              FPSModeType
 	iconImages           []image.Image
+	iconPair             bool
 	cursorShape          CursorShape
+	customCursor         *customCursor
+	customCursorUpdated  bool
 	windowClosingHandled bool
 	windowResizingMode   WindowResizingMode
 
@@ -80,6 +83,7 @@ type userInterfaceImpl struct {
 	initWindowFloating         bool
 	initWindowMaximized        bool
 	initWindowMousePassthrough bool
+	initWindowOpacity          float64
 
 	// bufferOnceSwapped must be accessed from the main thread.
 	bufferOnceSwapped bool
@@ -89,6 +93,18 @@ type userInterfaceImpl struct {
 	origWindowWidthInDIP  int
 	origWindowHeightInDIP int
 
+	// floatingBeforeFullscreen is the floating state of the window before entering fullscreen.
+	// A fullscreen window is not floating, and the state is restored when exiting fullscreen.
+	// floatingBeforeFullscreen must be accessed from the main thread.
+	floatingBeforeFullscreen bool
+
+	// glfwCustomCursors and glfwCustomCursorIndex must be accessed from the main thread.
+	glfwCustomCursors     []*glfw.Cursor
+	glfwCustomCursorIndex int
+
+	// customCursorAnimation must be accessed only from updateCustomCursorIfNeeded.
+	customCursorAnimation customCursorAnimation
+
 	fpsModeInited bool
 
 	inputState   InputState
@@ -130,6 +146,7 @@ func (u *UserInterface) init() error {
 		maxWindowHeightInDIP:     glfw.DontCare,
 		initCursorMode:           CursorModeVisible,
 		initWindowDecorated:      true,
+		initWindowOpacity:        1,
 		initWindowPositionXInDIP: invalidPos,
 		initWindowPositionYInDIP: invalidPos,
 		initWindowWidthInDIP:     640,
@@ -412,12 +429,34 @@ func (u *UserInterface) getCursorShape() CursorShape {
 	return v
 }
 
-func (u *UserInterface) setCursorShape(shape CursorShape) CursorShape {
+// setCursorShape sets the cursor shape and cancels the custom cursor.
+// setCursorShape returns the old cursor shape, and whether a custom cursor was set.
+func (u *UserInterface) setCursorShape(shape CursorShape) (CursorShape, bool) {
 	u.m.Lock()
+	defer u.m.Unlock()
 	old := u.cursorShape
 	u.cursorShape = shape
-	u.m.Unlock()
-	return old
+	custom := u.customCursor != nil
+	if custom {
+		u.customCursor = nil
+		u.customCursorUpdated = true
+	}
+	return old, custom
+}
+
+func (u *UserInterface) getAndResetCustomCursor() (*customCursor, bool) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	c, updated := u.customCursor, u.customCursorUpdated
+	u.customCursorUpdated = false
+	return c, updated
+}
+
+func (u *UserInterface) setCustomCursor(cursor *customCursor) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.customCursor = cursor
+	u.customCursorUpdated = true
 }
 
 func (u *UserInterface) isInitWindowDecorated() bool {
@@ -446,12 +485,13 @@ func (u *UserInterface) setRunnableOnUnfocused(runnableOnUnfocused bool) {
 	u.m.Unlock()
 }
 
-func (u *UserInterface) getAndResetIconImages() []image.Image {
-	u.m.RLock()
-	defer u.m.RUnlock()
+// getAndResetIconImages returns the icon images to set, and whether the images are a pair of a small and a large icon.
+func (u *UserInterface) getAndResetIconImages() ([]image.Image, bool) {
+	u.m.Lock()
+	defer u.m.Unlock()
 	s := u.iconImages
 	u.iconImages = nil
-	return s
+	return s, u.iconPair
 }
 
 func (u *UserInterface) setIconImages(iconImages []image.Image) {
@@ -463,6 +503,15 @@ func (u *UserInterface) setIconImages(iconImages []image.Image) {
 	// See the comment in updateIconIfNeeded.
 	u.iconImages = make([]image.Image, len(iconImages))
 	copy(u.iconImages, iconImages)
+	u.iconPair = false
+}
+
+func (u *UserInterface) setIconPair(small, large image.Image) {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	u.iconImages = []image.Image{small, large}
+	u.iconPair = true
 }
 
 func (u *UserInterface) getInitWindowPositionInDIP() (int, int) {
@@ -558,6 +607,18 @@ func (u *UserInterface) setInitWindowMousePassthrough(enabled bool) {
 	u.initWindowMousePassthrough = enabled
 }
 
+func (u *UserInterface) getInitWindowOpacity() float64 {
+	u.m.RLock()
+	defer u.m.RUnlock()
+	return u.initWindowOpacity
+}
+
+func (u *UserInterface) setInitWindowOpacity(opacity float64) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.initWindowOpacity = opacity
+}
+
 func (u *UserInterface) isWindowClosingHandled() bool {
 	u.m.RLock()
 	v := u.windowClosingHandled
@@ -769,7 +830,7 @@ func (u *UserInterface) SetCursorMode(mode CursorMode) {
 			return
 		}
 		if mode == CursorModeVisible {
-			if err := u.window.SetCursor(glfwSystemCursors[u.getCursorShape()]); err != nil {
+			if err := u.window.SetCursor(u.glfwCursor()); err != nil {
 				u.setError(err)
 				return
 			}
@@ -786,8 +847,8 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 		return
 	}
 
-	old := u.setCursorShape(shape)
-	if old == shape {
+	old, custom := u.setCursorShape(shape)
+	if old == shape && !custom {
 		return
 	}
 	if !u.isRunning() {
@@ -797,6 +858,10 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 		if u.isTerminated() {
 			return
 		}
+		if err := u.destroyGLFWCustomCursors(); err != nil {
+			u.setError(err)
+			return
+		}
 		if err := u.window.SetCursor(glfwSystemCursors[shape]); err != nil {
 			u.setError(err)
 			return
@@ -804,6 +869,35 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	})
 }
 
+func (u *UserInterface) SetCustomCursor(frames []image.Image, hotspot image.Point, fps int) {
+	if u.isTerminated() {
+		return
+	}
+	u.setCustomCursor(newCustomCursor(frames, hotspot, fps))
+}
+
+// glfwCursor returns the GLFW cursor to show.
+//
+// glfwCursor must be called from the main thread.
+func (u *UserInterface) glfwCursor() *glfw.Cursor {
+	if len(u.glfwCustomCursors) > 0 {
+		return u.glfwCustomCursors[u.glfwCustomCursorIndex]
+	}
+	return glfwSystemCursors[u.getCursorShape()]
+}
+
+// destroyGLFWCustomCursors must be called from the main thread.
+func (u *UserInterface) destroyGLFWCustomCursors() error {
+	for _, c := range u.glfwCustomCursors {
+		if err := c.Destroy(); err != nil {
+			return err
+		}
+	}
+	u.glfwCustomCursors = nil
+	u.glfwCustomCursorIndex = 0
+	return nil
+}
+
 // createWindow creates a GLFW window.
 //
 // createWindow must be called from the main thread.
@@ -869,6 +963,13 @@ func (u *UserInterface) createWindow() error {
 	}
 	// Icons are set after every frame. They don't have to be cared here.
 
+	// Opacity is not a window hint and must be set after the window is created.
+	if o := u.getInitWindowOpacity(); o < 1 {
+		if err := u.setWindowOpacity(o); err != nil {
+			return err
+		}
+	}
+
 	if err := u.updateWindowSizeLimits(); err != nil {
 		return err
 	}
@@ -1416,6 +1517,8 @@ func (u *UserInterface) loopGame() (ferr error) {
 }
 
 func (u *UserInterface) updateGame() error {
+	u.frameLimiter.waitForNextFrame()
+
 	var unfocused bool
 
 	// On Windows, the focusing state might be always false (#987).
@@ -1489,7 +1592,7 @@ func (u *UserInterface) updateIconIfNeeded() error {
 		return nil
 	}
 
-	imgs := u.getAndResetIconImages()
+	imgs, pair := u.getAndResetIconImages()
 	// A 0-size slice and nil are distinguished here.
 	// A 0-size slice means a user indicates to reset the icon.
 	// On the other hand, nil means a user didn't update the icon state.
@@ -1522,6 +1625,10 @@ func (u *UserInterface) updateIconIfNeeded() error {
 	}
 
 	u.mainThread.Call(func() {
+		if pair {
+			err = u.window.SetIconPair(newImgs[0], newImgs[1])
+			return
+		}
 		err = u.window.SetIcon(newImgs)
 	})
 	if err != nil {
@@ -1531,6 +1638,61 @@ func (u *UserInterface) updateIconIfNeeded() error {
 	return nil
 }
 
+func (u *UserInterface) updateCustomCursorIfNeeded() error {
+	c, updated := u.getAndResetCustomCursor()
+	if updated {
+		var imgs []*image.RGBA
+		var hotspot image.Point
+		var fps int
+		if c != nil {
+			imgs = c.rgbaFrames()
+			hotspot = c.hotspot
+			fps = c.fps
+
+			// Catch a possible error at 'At' (#2647).
+			if err := u.error(); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		u.mainThread.Call(func() {
+			if err = u.destroyGLFWCustomCursors(); err != nil {
+				return
+			}
+			for _, img := range imgs {
+				var cursor *glfw.Cursor
+				cursor, err = glfw.CreateCursor(img, hotspot.X, hotspot.Y)
+				if err != nil {
+					return
+				}
+				u.glfwCustomCursors = append(u.glfwCustomCursors, cursor)
+			}
+			err = u.window.SetCursor(u.glfwCursor())
+		})
+		if err != nil {
+			return err
+		}
+		u.customCursorAnimation.reset(len(imgs), fps)
+		return nil
+	}
+
+	idx, ok := u.customCursorAnimation.update()
+	if !ok {
+		return nil
+	}
+	var err error
+	u.mainThread.Call(func() {
+		// The custom cursor might be already canceled by SetCursorShape.
+		if idx >= len(u.glfwCustomCursors) {
+			return
+		}
+		u.glfwCustomCursorIndex = idx
+		err = u.window.SetCursor(u.glfwCursor())
+	})
+	return err
+}
+
 // updateWindowSizeLimits must be called from the main thread.
 func (u *UserInterface) updateWindowSizeLimits() error {
 	m, err := u.currentMonitor()
@@ -1707,6 +1869,32 @@ func (u *UserInterface) setFullscreen(fullscreen bool) error {
 			return err
 		}
 
+		// Without the native fullscreen, a monitor is required. Check this before changing the floating state
+		// so that the state is not changed when entering the fullscreen is aborted.
+		var m *Monitor
+		if !u.isNativeFullscreenAvailable() {
+			m, err = u.currentMonitor()
+			if err != nil {
+				return err
+			}
+			if m == nil {
+				return nil
+			}
+		}
+
+		// A floating window would stay above the other applications' windows even after switching to them.
+		// Also, a floating window cannot enter the native fullscreen on macOS.
+		floating, err := u.isWindowFloating()
+		if err != nil {
+			return err
+		}
+		if floating {
+			if err := u.setWindowFloatingAttrib(false); err != nil {
+				return err
+			}
+		}
+		u.floatingBeforeFullscreen = floating
+
 		if x, y := u.origWindowPos(); x == invalidPos || y == invalidPos {
 			x, y, err := u.window.GetPos()
 			if err != nil {
@@ -1720,14 +1908,6 @@ func (u *UserInterface) setFullscreen(fullscreen bool) error {
 				return err
 			}
 		} else {
-			m, err := u.currentMonitor()
-			if err != nil {
-				return err
-			}
-			if m == nil {
-				return nil
-			}
-
 			vm := m.videoMode
 			if err := u.window.SetMonitor(m.m, 0, 0, vm.Width, vm.Height, vm.RefreshRate); err != nil {
 				return err
@@ -1805,6 +1985,13 @@ func (u *UserInterface) setFullscreen(fullscreen bool) error {
 		}
 	}
 
+	if u.floatingBeforeFullscreen {
+		if err := u.setWindowFloatingAttrib(true); err != nil {
+			return err
+		}
+		u.floatingBeforeFullscreen = false
+	}
+
 	return nil
 }
 
@@ -2013,12 +2200,48 @@ func (u *UserInterface) setWindowDecorated(decorated bool) error {
 	return nil
 }
 
+// isWindowFloating must be called from the main thread.
+func (u *UserInterface) isWindowFloating() (bool, error) {
+	if microsoftgdk.IsXbox() {
+		return false, nil
+	}
+
+	f, err := u.isFullscreen()
+	if err != nil {
+		return false, err
+	}
+	if f {
+		return u.floatingBeforeFullscreen, nil
+	}
+
+	a, err := u.window.GetAttrib(glfw.Floating)
+	if err != nil {
+		return false, err
+	}
+	return a == glfw.True, nil
+}
+
 // setWindowFloating must be called from the main thread.
 func (u *UserInterface) setWindowFloating(floating bool) error {
 	if microsoftgdk.IsXbox() {
 		return nil
 	}
 
+	// In fullscreen, the state is applied when exiting fullscreen.
+	f, err := u.isFullscreen()
+	if err != nil {
+		return err
+	}
+	if f {
+		u.floatingBeforeFullscreen = floating
+		return nil
+	}
+
+	return u.setWindowFloatingAttrib(floating)
+}
+
+// setWindowFloatingAttrib must be called from the main thread.
+func (u *UserInterface) setWindowFloatingAttrib(floating bool) error {
 	v := glfw.False
 	if floating {
 		v = glfw.True
@@ -2131,6 +2354,29 @@ func (u *UserInterface) setWindowMousePassthrough(enabled bool) error {
 	return nil
 }
 
+// windowOpacity must be called from the main thread.
+func (u *UserInterface) windowOpacity() (float64, error) {
+	if microsoftgdk.IsXbox() {
+		return 1, nil
+	}
+	o, err := u.window.GetOpacity()
+	if err != nil {
+		return 0, err
+	}
+	return float64(o), nil
+}
+
+// setWindowOpacity must be called from the main thread.
+func (u *UserInterface) setWindowOpacity(opacity float64) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+	if err := u.window.SetOpacity(float32(opacity)); err != nil {
+		return err
+	}
+	return nil
+}
+
 func IsScreenTransparentAvailable() bool {
 	return true
 }
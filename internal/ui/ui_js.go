@@ -15,8 +15,13 @@
 package ui
 
 import (
+	"bytes"
+	"encoding/base64"
 	"errors"
+	"image"
+	"image/png"
 	"math"
+	"strconv"
 	"sync"
 	"syscall/js"
 	"time"
@@ -94,6 +99,8 @@ type userInterfaceImpl struct {
 	cursorPrevMode      CursorMode
 	captureCursorLater  bool
 	cursorShape         CursorShape
+	customCursor        *customCursor
+	customCursorUpdated bool
 	onceUpdateCalled    bool
 	lastCaptureExitTime time.Time
 
@@ -113,6 +120,11 @@ type userInterfaceImpl struct {
 	outsideSizeUnchangedCount int
 
 	keyboardLayoutMap js.Value
+	keyNames          keyNames
+
+	cssCustomCursors      []string
+	cssCustomCursorIndex  int
+	customCursorAnimation customCursorAnimation
 
 	m         sync.Mutex
 	dropFileM sync.Mutex
@@ -230,7 +242,7 @@ func (u *UserInterface) setCursorMode(mode CursorMode) {
 	u.cursorMode = mode
 	switch mode {
 	case CursorModeVisible:
-		canvas.Get("style").Set("cursor", driverCursorShapeToCSSCursor(u.cursorShape))
+		canvas.Get("style").Set("cursor", u.cssCursor())
 	case CursorModeHidden:
 		canvas.Get("style").Set("cursor", stringNone)
 	case CursorModeCaptured:
@@ -256,14 +268,34 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	if !canvas.Truthy() {
 		return
 	}
-	if u.cursorShape == shape {
+	if u.cursorShape == shape && u.customCursor == nil && len(u.cssCustomCursors) == 0 {
 		return
 	}
 
 	u.cursorShape = shape
+	u.customCursor = nil
+	u.customCursorUpdated = true
+	u.cssCustomCursors = nil
+	u.cssCustomCursorIndex = 0
 	if u.cursorMode == CursorModeVisible {
-		canvas.Get("style").Set("cursor", driverCursorShapeToCSSCursor(u.cursorShape))
+		canvas.Get("style").Set("cursor", u.cssCursor())
+	}
+}
+
+func (u *UserInterface) SetCustomCursor(frames []image.Image, hotspot image.Point, fps int) {
+	if !canvas.Truthy() {
+		return
+	}
+	u.customCursor = newCustomCursor(frames, hotspot, fps)
+	u.customCursorUpdated = true
+}
+
+// cssCursor returns the CSS cursor value to show.
+func (u *UserInterface) cssCursor() string {
+	if len(u.cssCustomCursors) > 0 {
+		return u.cssCustomCursors[u.cssCustomCursorIndex]
 	}
+	return driverCursorShapeToCSSCursor(u.cursorShape)
 }
 
 func (u *UserInterface) outsideSize() (float64, float64) {
@@ -388,6 +420,7 @@ func (u *UserInterface) loopGame() error {
 				u.onceUpdateCalled = true
 			}()
 			u.renderingScheduled = false
+			u.frameLimiter.waitForNextFrame()
 			if err := u.update(); err != nil {
 				close(reqStopAudioCh)
 				<-resStopAudioCh
@@ -803,6 +836,20 @@ func (m *Monitor) Size() (int, int) {
 	return screen.Get("width").Int(), screen.Get("height").Int()
 }
 
+func (m *Monitor) RefreshRate() int {
+	// A browser doesn't expose the refresh rate.
+	return 0
+}
+
+func (m *Monitor) AppendVideoModes(modes []VideoMode) []VideoMode {
+	w, h := m.Size()
+	s := m.DeviceScaleFactor()
+	return append(modes, VideoMode{
+		Width:  int(float64(w) * s),
+		Height: int(float64(h) * s),
+	})
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -815,6 +862,51 @@ func (u *UserInterface) updateIconIfNeeded() error {
 	return nil
 }
 
+func (u *UserInterface) updateCustomCursorIfNeeded() error {
+	if !canvas.Truthy() {
+		return nil
+	}
+
+	if u.customCursorUpdated {
+		u.customCursorUpdated = false
+		u.cssCustomCursors = nil
+		u.cssCustomCursorIndex = 0
+
+		var fps int
+		if c := u.customCursor; c != nil {
+			fps = c.fps
+			// A fallback is required for the cursor property with an image.
+			fallback := driverCursorShapeToCSSCursor(u.cursorShape)
+			for _, img := range c.rgbaFrames() {
+				var buf bytes.Buffer
+				if err := png.Encode(&buf, img); err != nil {
+					return err
+				}
+				v := "url(data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()) + ") " +
+					strconv.Itoa(c.hotspot.X) + " " + strconv.Itoa(c.hotspot.Y) + ", " + fallback
+				u.cssCustomCursors = append(u.cssCustomCursors, v)
+			}
+
+			// Catch a possible error at 'At' (#2647).
+			if err := u.error(); err != nil {
+				return err
+			}
+		}
+		u.customCursorAnimation.reset(len(u.cssCustomCursors), fps)
+	} else {
+		idx, ok := u.customCursorAnimation.update()
+		if !ok || idx >= len(u.cssCustomCursors) {
+			return nil
+		}
+		u.cssCustomCursorIndex = idx
+	}
+
+	if u.cursorMode == CursorModeVisible {
+		canvas.Get("style").Set("cursor", u.cssCursor())
+	}
+	return nil
+}
+
 func IsScreenTransparentAvailable() bool {
 	return true
 }
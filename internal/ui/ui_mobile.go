@@ -19,6 +19,7 @@ package ui
 import (
 	stdcontext "context"
 	"fmt"
+	"image"
 	"runtime"
 	"runtime/debug"
 	"sync"
@@ -207,6 +208,10 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	// Do nothing
 }
 
+func (u *UserInterface) SetCustomCursor(frames []image.Image, hotspot image.Point, fps int) {
+	// Do nothing
+}
+
 func (u *UserInterface) IsFullscreen() bool {
 	return false
 }
@@ -284,6 +289,16 @@ func (m *Monitor) Size() (int, int) {
 	return 0, 0
 }
 
+func (m *Monitor) RefreshRate() int {
+	// TODO: Return a valid value.
+	return 0
+}
+
+func (m *Monitor) AppendVideoModes(modes []VideoMode) []VideoMode {
+	// TODO: Return valid values.
+	return modes
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -319,6 +334,10 @@ func (u *UserInterface) updateIconIfNeeded() error {
 	return nil
 }
 
+func (u *UserInterface) updateCustomCursorIfNeeded() error {
+	return nil
+}
+
 func IsScreenTransparentAvailable() bool {
 	return false
 }
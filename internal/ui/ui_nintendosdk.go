@@ -22,6 +22,7 @@ import "C"
 
 import (
 	"errors"
+	"image"
 	"runtime"
 	"sync"
 
@@ -122,6 +123,9 @@ func (*UserInterface) CursorShape() CursorShape {
 func (*UserInterface) SetCursorShape(shape CursorShape) {
 }
 
+func (*UserInterface) SetCustomCursor(frames []image.Image, hotspot image.Point, fps int) {
+}
+
 func (*UserInterface) IsFullscreen() bool {
 	return false
 }
@@ -154,6 +158,10 @@ func (u *UserInterface) updateIconIfNeeded() error {
 	return nil
 }
 
+func (u *UserInterface) updateCustomCursorIfNeeded() error {
+	return nil
+}
+
 type Monitor struct{}
 
 var theMonitor = &Monitor{}
@@ -170,6 +178,18 @@ func (m *Monitor) Size() (int, int) {
 	return int(C.kScreenWidth), int(C.kScreenHeight)
 }
 
+func (m *Monitor) RefreshRate() int {
+	return 0
+}
+
+func (m *Monitor) AppendVideoModes(modes []VideoMode) []VideoMode {
+	w, h := m.Size()
+	return append(modes, VideoMode{
+		Width:  w,
+		Height: h,
+	})
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
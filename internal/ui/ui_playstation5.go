@@ -110,6 +110,9 @@ func (*UserInterface) CursorShape() CursorShape {
 func (*UserInterface) SetCursorShape(shape CursorShape) {
 }
 
+func (*UserInterface) SetCustomCursor(frames []image.Image, hotspot image.Point, fps int) {
+}
+
 func (*UserInterface) IsFullscreen() bool {
 	return false
 }
@@ -142,6 +145,10 @@ func (u *UserInterface) updateIconIfNeeded() error {
 	return nil
 }
 
+func (u *UserInterface) updateCustomCursorIfNeeded() error {
+	return nil
+}
+
 type Monitor struct{}
 
 var theMonitor = &Monitor{}
@@ -163,6 +170,18 @@ func (m *Monitor) Size() (int, int) {
 	return screenWidth, screenHeight
 }
 
+func (m *Monitor) RefreshRate() int {
+	return 0
+}
+
+func (m *Monitor) AppendVideoModes(modes []VideoMode) []VideoMode {
+	w, h := m.Size()
+	return append(modes, VideoMode{
+		Width:  w,
+		Height: h,
+	})
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
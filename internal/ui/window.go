@@ -37,12 +37,15 @@ type Window interface {
 	Minimize()
 	IsMinimized() bool
 	SetIcon(iconImages []image.Image)
+	SetIconPair(small, large image.Image)
 	SetTitle(title string)
 	Restore()
 	SetClosingHandled(handled bool)
 	IsClosingHandled() bool
 	SetMousePassthrough(enabled bool)
 	IsMousePassthrough() bool
+	SetOpacity(opacity float64)
+	Opacity() float64
 }
 
 type nullWindow struct{}
@@ -109,6 +112,9 @@ func (*nullWindow) IsMinimized() bool {
 func (*nullWindow) SetIcon(iconImages []image.Image) {
 }
 
+func (*nullWindow) SetIconPair(small, large image.Image) {
+}
+
 func (*nullWindow) SetTitle(title string) {
 }
 
@@ -128,3 +134,10 @@ func (*nullWindow) SetMousePassthrough(enabled bool) {
 func (*nullWindow) IsMousePassthrough() bool {
 	return false
 }
+
+func (*nullWindow) SetOpacity(opacity float64) {
+}
+
+func (*nullWindow) Opacity() float64 {
+	return 1
+}
@@ -122,12 +122,12 @@ func (w *glfwWindow) IsFloating() bool {
 		if w.ui.isTerminated() {
 			return
 		}
-		a, err := w.ui.window.GetAttrib(glfw.Floating)
+		f, err := w.ui.isWindowFloating()
 		if err != nil {
 			w.ui.setError(err)
 			return
 		}
-		v = a == glfw.True
+		v = f
 	})
 	return v
 }
@@ -436,6 +436,14 @@ func (w *glfwWindow) SetIcon(iconImages []image.Image) {
 	w.ui.setIconImages(iconImages)
 }
 
+func (w *glfwWindow) SetIconPair(small, large image.Image) {
+	if w.ui.isTerminated() {
+		return
+	}
+	// The icons are actually set at (*UserInterface).loop.
+	w.ui.setIconPair(small, large)
+}
+
 func (w *glfwWindow) SetTitle(title string) {
 	if w.ui.isTerminated() {
 		return
@@ -506,3 +514,44 @@ func (w *glfwWindow) IsMousePassthrough() bool {
 	})
 	return v
 }
+
+func (w *glfwWindow) SetOpacity(opacity float64) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.isRunning() {
+		w.ui.setInitWindowOpacity(opacity)
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		if err := w.ui.setWindowOpacity(opacity); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
+func (w *glfwWindow) Opacity() float64 {
+	if w.ui.isTerminated() {
+		return 1
+	}
+	if !w.ui.isRunning() {
+		return w.ui.getInitWindowOpacity()
+	}
+	v := 1.0
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		o, err := w.ui.windowOpacity()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+		v = o
+	})
+	return v
+}
@@ -26,6 +26,10 @@ import (
 // A Key represents a keyboard key.
 // These keys represent physical keys of US keyboard.
 // For example, KeyQ represents Q key on US keyboards and ' (quote) key on Dvorak keyboards.
+//
+// A Key is based on the position of a key and doesn't depend on the keyboard layout.
+// This is suitable for key bindings like WASD, which should be at the same positions on any layouts.
+// To show the name of a key for the current keyboard layout e.g. in a key binding setting, use KeyName.
 type Key int
 
 // Keys.
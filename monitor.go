@@ -32,6 +32,9 @@ func (m *MonitorType) Name() string {
 // DeviceScaleFactor returns a meaningful value on high-DPI display environment,
 // otherwise DeviceScaleFactor returns 1.
 //
+// The device scale factor is the number of physical pixels per device-independent pixel.
+// See also the document of Game.Layout.
+//
 // DeviceScaleFactor might panic on init function on some devices like Android.
 // Then, it is not recommended to call DeviceScaleFactor from init functions.
 func (m *MonitorType) DeviceScaleFactor() float64 {
@@ -51,6 +54,42 @@ func (m *MonitorType) Size() (int, int) {
 	return (*ui.Monitor)(m).Size()
 }
 
+// RefreshRate returns the refresh rate of the monitor's current video mode in Hz.
+//
+// RefreshRate returns 0 if the refresh rate is unknown, e.g. on browsers and mobiles.
+func (m *MonitorType) RefreshRate() int {
+	return (*ui.Monitor)(m).RefreshRate()
+}
+
+// MonitorVideoMode represents a video mode supported by a monitor.
+type MonitorVideoMode struct {
+	// Width is the width of the video mode in device pixels.
+	Width int
+
+	// Height is the height of the video mode in device pixels.
+	Height int
+
+	// RefreshRate is the refresh rate of the video mode in Hz.
+	// RefreshRate is 0 if the refresh rate is unknown.
+	RefreshRate int
+}
+
+// AppendVideoModes appends the video modes supported by the monitor to modes, and returns the extended buffer.
+//
+// On browsers, AppendVideoModes appends only one video mode representing the screen.
+// On mobiles, AppendVideoModes appends nothing so far.
+func (m *MonitorType) AppendVideoModes(modes []MonitorVideoMode) []MonitorVideoMode {
+	// TODO: This is not an efficient operation as well as AppendMonitors.
+	for _, v := range (*ui.Monitor)(m).AppendVideoModes(nil) {
+		modes = append(modes, MonitorVideoMode{
+			Width:       v.Width,
+			Height:      v.Height,
+			RefreshRate: v.RefreshRate,
+		})
+	}
+	return modes
+}
+
 // Monitor returns the current monitor.
 func Monitor() *MonitorType {
 	m := ui.Get().Monitor()
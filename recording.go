@@ -0,0 +1,202 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/recording"
+)
+
+// RecordingFormat represents a file format of a recording.
+type RecordingFormat int
+
+const (
+	// RecordingFormatGIF is an animated GIF.
+	// The colors are reduced to a fixed palette of 216 colors with dithering, and transparency is not kept.
+	RecordingFormatGIF RecordingFormat = iota
+
+	// RecordingFormatAPNG is an animated PNG.
+	// The colors are kept as they are, but the encoded data tends to be bigger than GIF.
+	RecordingFormatAPNG
+)
+
+// RecordingOptions represents options for StartRecording.
+type RecordingOptions struct {
+	// Format is the file format of the recording.
+	//
+	// The default (zero) value is RecordingFormatGIF.
+	Format RecordingFormat
+
+	// FPS is the target number of frames recorded per second.
+	// If the game draws less frequently than FPS, a frame is shown longer in the recording.
+	//
+	// The default (zero) value is 30.
+	FPS int
+}
+
+type recorder struct {
+	format RecordingFormat
+	fps    int
+	start  time.Time
+
+	encoder recording.Encoder
+
+	// pending is the last captured frame, which is not encoded since its duration is not determined yet.
+	pending     *image.RGBA
+	pendingSlot int
+	hasPending  bool
+
+	current *image.RGBA
+	tmp     *image.RGBA
+
+	err error
+	m   sync.Mutex
+}
+
+var theRecorder atomic.Pointer[recorder]
+
+// StartRecording starts recording the screen as an animated image, and returns a function to stop the recording.
+//
+// The screen image given to Draw is captured at the end of each Draw at most options.FPS times per second.
+// Unlike CaptureScreen, the post-processing to render the final screen is not included.
+// As reading pixels from GPU is slow, recording affects the game's performance.
+// The size of the recording is the screen size at the first captured frame.
+// If the screen size is changed during the recording, the following frames are cropped or padded.
+//
+// Each frame is encoded as soon as the next frame is captured, and only the encoded data is kept in memory.
+//
+// stop finishes the encoding and returns the encoded stream.
+// stop returns an error if encoding fails, no frame is captured, or stop is called more than once.
+//
+// StartRecording is concurrent-safe. StartRecording panics if a recording is already in progress.
+func StartRecording(options RecordingOptions) (stop func() (io.Reader, error)) {
+	fps := options.FPS
+	if fps <= 0 {
+		fps = 30
+	}
+	r := &recorder{
+		format: options.Format,
+		fps:    fps,
+		start:  time.Now(),
+	}
+	if !theRecorder.CompareAndSwap(nil, r) {
+		panic("ebiten: StartRecording cannot be called while recording")
+	}
+
+	var stopped atomic.Bool
+	return func() (io.Reader, error) {
+		if !stopped.CompareAndSwap(false, true) {
+			return nil, errors.New("ebiten: stop is already called")
+		}
+		theRecorder.CompareAndSwap(r, nil)
+		return r.finish()
+	}
+}
+
+// recordFrame records the screen image if a recording is in progress.
+//
+// recordFrame must be called at the end of Draw.
+func recordFrame(screen *Image, transparent bool) {
+	r := theRecorder.Load()
+	if r == nil {
+		return
+	}
+	r.recordFrame(screen, transparent)
+}
+
+func (r *recorder) recordFrame(screen *Image, transparent bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.err != nil {
+		return
+	}
+
+	slot := int(time.Since(r.start) * time.Duration(r.fps) / time.Second)
+	if r.hasPending && slot <= r.pendingSlot {
+		return
+	}
+
+	if r.encoder == nil {
+		w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
+		var err error
+		switch r.format {
+		case RecordingFormatGIF:
+			r.encoder, err = recording.NewGIFEncoder(w, h, r.fps)
+		case RecordingFormatAPNG:
+			r.encoder, err = recording.NewAPNGEncoder(w, h, r.fps)
+		default:
+			err = fmt.Errorf("ebiten: invalid recording format: %d", r.format)
+		}
+		if err != nil {
+			r.err = err
+			return
+		}
+		r.pending = image.NewRGBA(image.Rect(0, 0, w, h))
+		r.current = image.NewRGBA(image.Rect(0, 0, w, h))
+	}
+
+	if screen.Bounds().Size() == r.current.Bounds().Size() {
+		screen.ReadPixels(r.current.Pix)
+	} else {
+		if r.tmp == nil || r.tmp.Bounds().Size() != screen.Bounds().Size() {
+			r.tmp = image.NewRGBA(image.Rect(0, 0, screen.Bounds().Dx(), screen.Bounds().Dy()))
+		}
+		screen.ReadPixels(r.tmp.Pix)
+		for i := range r.current.Pix {
+			r.current.Pix[i] = 0
+		}
+		draw.Draw(r.current, r.current.Bounds(), r.tmp, image.Point{}, draw.Src)
+	}
+	if !transparent {
+		fillAlphaOpaque(r.current.Pix)
+	}
+
+	// The duration of the pending frame is determined now.
+	if r.hasPending {
+		if err := r.encoder.EncodeFrame(r.pending, slot-r.pendingSlot); err != nil {
+			r.err = err
+			return
+		}
+	}
+	r.pending, r.current = r.current, r.pending
+	r.pendingSlot = slot
+	r.hasPending = true
+}
+
+func (r *recorder) finish() (io.Reader, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	if !r.hasPending {
+		return nil, errors.New("ebiten: no frame is recorded")
+	}
+	if err := r.encoder.EncodeFrame(r.pending, 1); err != nil {
+		return nil, err
+	}
+	r.hasPending = false
+	return r.encoder.Finish()
+}
@@ -16,12 +16,16 @@ package ebiten
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"image/color"
 	"io/fs"
 	"sync/atomic"
+	"time"
 
+	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
 	"github.com/hajimehoshi/ebiten/v2/internal/clock"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -69,8 +73,16 @@ type Game interface {
 	// On desktops, the outside is a window or a monitor (fullscreen mode). On browsers, the outside is a body
 	// element. On mobiles, the outside is the view's size.
 	//
+	// A device-independent pixel is a logical unit of the platform. The size of the outside in physical pixels
+	// is the outside size multiplied by the device scale factor, which Monitor().DeviceScaleFactor returns.
+	// For example, an outside of 640x480 device-independent pixels is 1280x960 physical pixels on a monitor
+	// whose device scale factor is 2.
+	//
+	// The screen image given to Draw has the screen size Layout returns, in pixels of the image.
 	// Even though the outside size and the screen size differ, the rendering scale is automatically adjusted to
 	// fit with the outside.
+	// Then, to render the screen without any scaling on a high-DPI display, return the outside size multiplied
+	// by the device scale factor.
 	//
 	// Layout is called almost every frame.
 	//
@@ -90,6 +102,11 @@ type LayoutFer interface {
 	// LayoutF is the float version of Game.Layout.
 	//
 	// If the game implements this interface, Layout is never called and LayoutF is called instead.
+	//
+	// The outside size is not rounded, unlike Layout. The outside size multiplied by the device scale factor is
+	// exactly the size of the outside in physical pixels.
+	// Returning the physical size makes a screen image whose one pixel is exactly one physical pixel,
+	// which is useful to render crisp texts.
 	LayoutF(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
 }
 
@@ -127,6 +144,111 @@ type FinalScreenDrawer interface {
 // DefaultTPS represents a default ticks per second, that represents how many times game updating happens in a second.
 const DefaultTPS = clock.DefaultTPS
 
+// FrameStats represents the statistics of the recent frame times.
+//
+// The durations are the intervals between frames, including the time of Update, Draw, and waiting for vsync.
+type FrameStats struct {
+	// FrameCount is the number of the recent frames used for the statistics.
+	// FrameCount is at most 120.
+	FrameCount int
+
+	// Min is the minimum frame time.
+	Min time.Duration
+
+	// Max is the maximum frame time.
+	Max time.Duration
+
+	// Average is the average frame time.
+	Average time.Duration
+
+	// P50 is the median frame time.
+	P50 time.Duration
+
+	// P95 is the 95th percentile frame time.
+	P95 time.Duration
+
+	// P99 is the 99th percentile frame time.
+	P99 time.Duration
+
+	// DroppedFrameCount is the number of the recent frames that took more than 1.5 times the median frame time.
+	DroppedFrameCount int
+}
+
+// ActualFrameStats returns the statistics of the recent frame times.
+//
+// ActualFrameStats is useful to diagnose stutters that ActualFPS cannot show, e.g. in a debug overlay.
+// The frame times are always recorded, and the recording is cheap.
+// The statistics are calculated when ActualFrameStats is called.
+//
+// GPU time is not measured separately so far.
+//
+// This value is for measurement and/or debug, and your game logic should not rely on this value.
+//
+// ActualFrameStats is concurrent-safe.
+func ActualFrameStats() FrameStats {
+	s := clock.ActualFrameStats()
+	return FrameStats{
+		FrameCount:        s.FrameCount,
+		Min:               s.Min,
+		Max:               s.Max,
+		Average:           s.Average,
+		P50:               s.P50,
+		P95:               s.P95,
+		P99:               s.P99,
+		DroppedFrameCount: s.DroppedFrameCount,
+	}
+}
+
+// ActualDrawCallCount returns the number of draw calls issued to the GPU in the last frame.
+//
+// A merged draw command is counted once for each destination region it draws to, as each region needs one draw call.
+// The additional calls that a graphics driver might issue internally for stencil-based fill rules are not counted.
+//
+// Ebitengine merges consecutive draw calls sharing the same states, e.g. the same source images, blend, and shader.
+// A large number implies that the draw order breaks batches.
+// To find where batches are broken, use BeginBatch and EndBatch with the build tag ebitenginedebug.
+//
+// This value is for measurement and/or debug, and your game logic should not rely on this value.
+//
+// ActualDrawCallCount is concurrent-safe.
+func ActualDrawCallCount() int {
+	return graphicscommand.LastFrameDrawCallCount()
+}
+
+// GPUMemoryUsage returns the approximate number of bytes of the GPU textures that Ebitengine allocates for images.
+//
+// The value includes the internal texture atlases, which might have free spaces, and mipmaps.
+// To reduce the usage, call Deallocate for images that are no longer needed, instead of waiting for the GC.
+//
+// This value is for measurement and/or debug, and your game logic should not rely on this value.
+//
+// GPUMemoryUsage is concurrent-safe.
+func GPUMemoryUsage() int {
+	return atlas.TextureMemoryUsage()
+}
+
+// BeginBatch begins a scope where the draw calls are expected to be batched.
+//
+// BeginBatch doesn't change how draw calls are batched.
+// With the build tag ebitenginedebug, a draw call that cannot be merged with the previous one
+// in the scope is logged with its reason, e.g. "different blend".
+//
+// BeginBatch and EndBatch can be nested.
+//
+// BeginBatch is concurrent-safe.
+func BeginBatch() {
+	graphicscommand.BeginBatchScope()
+}
+
+// EndBatch ends a scope begun by BeginBatch.
+//
+// EndBatch panics if there is no corresponding BeginBatch.
+//
+// EndBatch is concurrent-safe.
+func EndBatch() {
+	graphicscommand.EndBatchScope()
+}
+
 // ActualFPS returns the current number of FPS (frames per second), that represents
 // how many swapping buffer happens per second.
 //
@@ -398,11 +520,54 @@ func CursorShape() CursorShapeType {
 //
 // If the platform doesn't implement the given shape, the default cursor shape is used.
 //
+// SetCursorShape cancels the custom cursor set by SetCustomCursor.
+//
 // SetCursorShape is concurrent-safe.
 func SetCursorShape(shape CursorShapeType) {
 	ui.Get().SetCursorShape(shape)
 }
 
+// SetCustomCursor sets a custom cursor image animated with frames.
+//
+// The frames are switched in order at fps frames per second, and loop.
+// If frames has only one image, or fps is 0 or less, the first frame is shown without an animation.
+// If frames is empty, SetCustomCursor cancels the current custom cursor.
+// hotspot is the point of the cursor position, relative to the upper-left corner of each frame.
+//
+// Each frame is used as a cursor image of the platform, so the cursor is not drawn on the screen.
+// On browsers, a cursor image bigger than 128x128 might be ignored, and 32x32 is recommended.
+//
+// The animation follows the elapsed time, but the frames are switched in the game loop.
+// Then, the frames are switched at most once per game frame, and the animation stops while the game loop doesn't run,
+// e.g. when the window is unfocused and IsRunnableOnUnfocused is false.
+//
+// The frames are read at the next frame, so modifying the frames after calling SetCustomCursor might affect the cursor.
+//
+// SetCursorShape or ResetCursor cancels the custom cursor.
+//
+// SetCustomCursor panics if frames includes nil.
+//
+// SetCustomCursor does nothing on mobiles.
+//
+// SetCustomCursor is concurrent-safe.
+func SetCustomCursor(frames []*Image, hotspot image.Point, fps int) {
+	imgs := make([]image.Image, len(frames))
+	for i, f := range frames {
+		if f == nil {
+			panic(fmt.Sprintf("ebiten: frames[%d] at SetCustomCursor must not be nil", i))
+		}
+		imgs[i] = f
+	}
+	ui.Get().SetCustomCursor(imgs, hotspot, fps)
+}
+
+// ResetCursor cancels the custom cursor set by SetCustomCursor, and sets the cursor shape to CursorShapeDefault.
+//
+// ResetCursor is concurrent-safe.
+func ResetCursor() {
+	ui.Get().SetCursorShape(CursorShapeDefault)
+}
+
 // IsFullscreen reports whether the current mode is fullscreen or not.
 //
 // IsFullscreen always returns false on mobiles.
@@ -467,6 +632,38 @@ func SetRunnableOnUnfocused(runnableOnUnfocused bool) {
 	ui.Get().SetRunnableOnUnfocused(runnableOnUnfocused)
 }
 
+// IsUpdatePaused reports whether the game's Update is paused by PauseUpdate.
+//
+// IsUpdatePaused is concurrent-safe.
+func IsUpdatePaused() bool {
+	return ui.Get().IsUpdatePaused()
+}
+
+// PauseUpdate pauses calling the game's Update until ResumeUpdate is called.
+//
+// While Update is paused, Draw is still called so that the game can render e.g. a paused overlay.
+// The input state is still updated once per frame, so Draw can check inputs e.g. to call ResumeUpdate.
+// The callback set by SetDropCallback is not called during the pause. The last files dropped during the pause are
+// passed to the callback after ResumeUpdate is called.
+// Audio is suspended during the pause, regardless of whether the game is focused.
+//
+// The ticks elapsed during the pause are discarded.
+// After ResumeUpdate is called, Update is called at the rate of TPS without catching up the paused time.
+//
+// If PauseUpdate is called before the first Update, Update is still called once so that Update can be used for initialization.
+//
+// PauseUpdate is concurrent-safe.
+func PauseUpdate() {
+	ui.Get().SetUpdatePaused(true)
+}
+
+// ResumeUpdate resumes calling the game's Update paused by PauseUpdate.
+//
+// ResumeUpdate is concurrent-safe.
+func ResumeUpdate() {
+	ui.Get().SetUpdatePaused(false)
+}
+
 // DeviceScaleFactor returns a device scale factor value of the current monitor which the window belongs to.
 //
 // DeviceScaleFactor returns a meaningful value on high-DPI display environment,
@@ -475,6 +672,9 @@ func SetRunnableOnUnfocused(runnableOnUnfocused bool) {
 // DeviceScaleFactor might panic on init function on some devices like Android.
 // Then, it is not recommended to call DeviceScaleFactor from init functions.
 //
+// The value can change when the window is moved to another monitor.
+// Use SetDeviceScaleFactorChangedCallback to be notified of the change.
+//
 // DeviceScaleFactor must be called on the main thread before the main loop, and is concurrent-safe after the main
 // loop.
 //
@@ -557,6 +757,33 @@ func SetFPSMode(mode FPSModeType) {
 	ui.Get().SetFPSMode(mode)
 }
 
+// TargetFPS returns the current maximum FPS set by SetTargetFPS.
+// TargetFPS returns 0 if FPS is not capped.
+//
+// TargetFPS is concurrent-safe.
+func TargetFPS() int {
+	return ui.Get().TargetFPS()
+}
+
+// SetTargetFPS sets the maximum FPS (frames per second), that represents how many times Draw is called per second.
+// The initial value is 0, which means FPS is not capped and is determined by the FPS mode.
+// If fps is 0 or negative, FPS is not capped.
+//
+// SetTargetFPS is useful to save battery power, e.g. rendering at 30 FPS on a 60 Hz display.
+// When vsync is enabled, the actual FPS might be lower than the target FPS
+// since a frame is presented at the display's refresh timing.
+//
+// SetTargetFPS doesn't affect TPS. Update is still called at the rate specified by SetTPS,
+// so Update might be called multiple times per frame.
+// If TPS is SyncWithFPS, Update is called once per frame and TPS follows the capped FPS.
+//
+// SetTargetFPS works only on desktops and browsers so far.
+//
+// SetTargetFPS is concurrent-safe.
+func SetTargetFPS(fps int) {
+	ui.Get().SetTargetFPS(fps)
+}
+
 // ScheduleFrame schedules a next frame when the current FPS mode is FPSModeVsyncOffMinimum.
 //
 // ScheduleFrame is concurrent-safe.
@@ -581,6 +808,24 @@ func MaxTPS() int {
 	return TPS()
 }
 
+// TickInterpolation returns how far the current frame is between the previous tick and the current tick, in [0, 1).
+//
+// TickInterpolation is useful to render objects smoothly when FPS and TPS differ.
+// A game can keep the states of the previous and the current ticks in Update,
+// and interpolate them with the returned value in Draw:
+//
+//	alpha := ebiten.TickInterpolation()
+//	x := prevX + (currX-prevX)*alpha
+//
+// The interpolated state is behind the current state by less than one tick, and never goes backward.
+//
+// If TPS is SyncWithFPS, TickInterpolation always returns 0.
+//
+// TickInterpolation is concurrent-safe.
+func TickInterpolation() float64 {
+	return clock.Interpolation()
+}
+
 // ActualTPS returns the current TPS (ticks per second),
 // that represents how many times Update function is called in a second.
 //
@@ -706,8 +951,77 @@ func toUIRunOptions(options *RunGameOptions) *ui.RunOptions {
 // at its root directory, at the time Update is called.
 //
 // DroppedFiles works on desktops and browsers.
+// To be notified of dropped files without polling, use SetDropCallback.
 //
 // DroppedFiles is concurrent-safe.
 func DroppedFiles() fs.FS {
 	return theInputState.droppedFiles()
 }
+
+var dropCallback atomic.Pointer[func(fsys fs.FS)]
+
+// SetDropCallback sets a function that is called when files and/or directories are dropped onto the window.
+//
+// callback is called on the tick when the files are dropped, right before Update is called.
+// fsys is the same virtual file system as DroppedFiles returns in the tick.
+// On browsers, fsys is made from the DataTransfer of the drop event.
+//
+// If files are dropped while Update is paused by PauseUpdate, callback is called right before the first Update
+// after ResumeUpdate, with the last dropped files. In this case, DroppedFiles returns nil in the tick.
+//
+// If callback is nil, the current function is unset.
+//
+// SetDropCallback works on desktops and browsers.
+//
+// SetDropCallback is concurrent-safe.
+func SetDropCallback(callback func(fsys fs.FS)) {
+	if callback == nil {
+		dropCallback.Store(nil)
+		return
+	}
+	dropCallback.Store(&callback)
+}
+
+func runDropCallback(fsys fs.FS) {
+	f := dropCallback.Load()
+	if f == nil {
+		return
+	}
+	if fsys == nil {
+		return
+	}
+	(*f)(fsys)
+}
+
+var deviceScaleFactorChangedCallback atomic.Pointer[func(deviceScaleFactor float64)]
+
+// SetDeviceScaleFactorChangedCallback sets a function that is called when the device scale factor changes.
+//
+// The device scale factor is the one of the monitor which the window belongs to, and it changes
+// e.g. when the window is moved to a monitor with a different scale, or when the scale setting of the monitor is changed.
+// deviceScaleFactor is the new value, which is the same as Monitor().DeviceScaleFactor returns.
+//
+// callback is called in the game loop, after Layout is called with the outside size for the new device scale factor,
+// and before Update is called.
+// The initial device scale factor at the first frame is not notified.
+//
+// If callback is nil, the current function is unset.
+//
+// On browsers, the device scale factor doesn't change during the game so far.
+//
+// SetDeviceScaleFactorChangedCallback is concurrent-safe.
+func SetDeviceScaleFactorChangedCallback(callback func(deviceScaleFactor float64)) {
+	if callback == nil {
+		deviceScaleFactorChangedCallback.Store(nil)
+		return
+	}
+	deviceScaleFactorChangedCallback.Store(&callback)
+}
+
+func runDeviceScaleFactorChangedCallback(deviceScaleFactor float64) {
+	f := deviceScaleFactorChangedCallback.Load()
+	if f == nil {
+		return
+	}
+	(*f)(deviceScaleFactor)
+}
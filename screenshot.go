@@ -0,0 +1,99 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"sync/atomic"
+)
+
+// theDrawingGame is the game whose Draw is being called.
+var theDrawingGame atomic.Pointer[gameForUI]
+
+// CaptureScreen returns a snapshot of the final screen as it would be presented with the current content of the screen image
+// given to Draw.
+//
+// CaptureScreen must be called from the game's Draw. Otherwise, CaptureScreen returns an error.
+// As the snapshot is taken at the call, call CaptureScreen at the end of Draw to capture the whole frame.
+//
+// The snapshot includes the post-processing to render the final screen, i.e. the scaling and the screen filter,
+// or FinalScreenDrawer if the game implements it. To take the snapshot, the final screen is rendered onto another image
+// in the same way as it is rendered at the end of the frame. Thus, FinalScreenDrawer's DrawFinalScreen is called once more
+// for each CaptureScreen call.
+//
+// The returned pixels are in the same color space as the screen, and have pre-multiplied alpha values as image.RGBA does.
+// The size of the returned image is the size of the final screen in device pixels, which is the window's content size
+// multiplied by the device scale factor.
+//
+// CaptureScreen is useful for an in-game screenshot key and automated visual testing.
+func CaptureScreen() (*image.RGBA, error) {
+	g := theDrawingGame.Load()
+	if g == nil {
+		return nil, errors.New("ebiten: CaptureScreen must be called from Draw")
+	}
+
+	// The final screen cannot be read back portably, e.g. on Metal and DirectX.
+	// Render the final screen onto a regular image instead.
+	screen := NewImage(g.screen.Bounds().Dx(), g.screen.Bounds().Dy())
+	defer screen.Deallocate()
+	g.drawFinalScreen(screen, g.screenScale, g.screenOffsetX, g.screenOffsetY)
+
+	img := image.NewRGBA(screen.Bounds())
+	screen.ReadPixels(img.Pix)
+	return img, nil
+}
+
+// CaptureScreenToFile takes a snapshot by CaptureScreen and saves it as a PNG file at path.
+//
+// Unless the screen is transparent by RunGameOptions.ScreenTransparent, the image is saved as an opaque image.
+//
+// CaptureScreenToFile must be called from the game's Draw. Otherwise, CaptureScreenToFile returns an error.
+func CaptureScreenToFile(path string) (err error) {
+	img, err := CaptureScreen()
+	if err != nil {
+		return err
+	}
+
+	if !theDrawingGame.Load().transparent {
+		fillAlphaOpaque(img.Pix)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := f.Close(); err1 != nil && err == nil {
+			err = err1
+		}
+	}()
+
+	if err := png.Encode(f, img); err != nil {
+		return err
+	}
+	return nil
+}
+
+// fillAlphaOpaque makes the pixels opaque.
+//
+// As the pixels have pre-multiplied alpha values, filling alpha values is the same as drawing on a black background.
+func fillAlphaOpaque(pix []byte) {
+	for i := 3; i < len(pix); i += 4 {
+		pix[i] = 0xff
+	}
+}
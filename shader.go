@@ -106,7 +106,8 @@ func builtinShader(filter builtinshader.Filter, address builtinshader.Address, u
 		case builtinshader.FilterLinear:
 			shader = &Shader{shader: ui.LinearFilterShader}
 		}
-	} else {
+	}
+	if shader == nil {
 		src := builtinshader.ShaderSource(filter, address, useColorM)
 		s, err := NewShader(src)
 		if err != nil {
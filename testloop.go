@@ -0,0 +1,110 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// TestLoop drives a game's Update tick by tick without a window or a GPU.
+//
+// TestLoop is useful to test a game's logic deterministically.
+// The input for a tick is given by TestLoop's setters, and is read by the input functions like IsKeyPressed
+// and the inpututil package in the same way as in the real game loop.
+//
+// TestLoop doesn't call Draw or Layout. Graphics functions like (*Image).ReadPixels don't work without a GPU.
+// Gamepads cannot be emulated so far.
+//
+// TestLoop must not be used while the real game loop is running, as the input state is shared.
+// TestLoop is not concurrent-safe.
+type TestLoop struct {
+	game  Game
+	input ui.InputState
+}
+
+// NewTestLoop creates a new TestLoop for the game.
+func NewTestLoop(game Game) *TestLoop {
+	return &TestLoop{
+		game: game,
+	}
+}
+
+// Step advances exactly one tick: it updates the input state with the given input and calls the game's Update.
+//
+// Step returns the error returned by Update.
+//
+// The deltas like the wheel offsets and the input characters are reset after the tick.
+func (t *TestLoop) Step() error {
+	theInputState.update(func(inputState *ui.InputState) {
+		*inputState = t.input
+		inputState.Runes = append([]rune(nil), t.input.Runes...)
+	})
+	t.input.WheelX = 0
+	t.input.WheelY = 0
+	t.input.Runes = t.input.Runes[:0]
+
+	if err := hook.RunBeforeUpdateHooks(); err != nil {
+		return err
+	}
+	return t.game.Update()
+}
+
+// SetKeyPressed sets whether the key is pressed.
+// The state is kept until SetKeyPressed is called with the same key again.
+//
+// For a virtual key like KeyShift, the left key like KeyShiftLeft is used.
+func (t *TestLoop) SetKeyPressed(key Key, pressed bool) {
+	if !key.isValid() {
+		return
+	}
+	switch key {
+	case KeyAlt:
+		key = KeyAltLeft
+	case KeyControl:
+		key = KeyControlLeft
+	case KeyShift:
+		key = KeyShiftLeft
+	case KeyMeta:
+		key = KeyMetaLeft
+	}
+	t.input.KeyPressed[key] = pressed
+}
+
+// SetMouseButtonPressed sets whether the mouse button is pressed.
+// The state is kept until SetMouseButtonPressed is called with the same button again.
+func (t *TestLoop) SetMouseButtonPressed(mouseButton MouseButton, pressed bool) {
+	if mouseButton < 0 || mouseButton > MouseButtonMax {
+		return
+	}
+	t.input.MouseButtonPressed[mouseButton] = pressed
+}
+
+// SetCursorPosition sets the cursor position in the logical screen coordinates.
+func (t *TestLoop) SetCursorPosition(x, y float64) {
+	t.input.CursorX = x
+	t.input.CursorY = y
+}
+
+// AddWheel adds the wheel offsets for the next tick.
+func (t *TestLoop) AddWheel(x, y float64) {
+	t.input.WheelX += x
+	t.input.WheelY += y
+}
+
+// AppendInputChars appends the input characters for the next tick.
+func (t *TestLoop) AppendInputChars(runes ...rune) {
+	t.input.Runes = append(t.input.Runes, runes...)
+}
@@ -0,0 +1,232 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+type countingGame struct {
+	count   int
+	errorAt int
+}
+
+var errCountingGame = errors.New("counting game error")
+
+func (g *countingGame) Update() error {
+	g.count++
+	if g.count == g.errorAt {
+		return errCountingGame
+	}
+	return nil
+}
+
+func (g *countingGame) Draw(screen *ebiten.Image) {
+}
+
+func (g *countingGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func TestTestLoopStep(t *testing.T) {
+	const n = 10
+
+	g := &countingGame{
+		errorAt: 5,
+	}
+	l := ebiten.NewTestLoop(g)
+	for i := 0; i < n; i++ {
+		err := l.Step()
+		if i+1 == g.errorAt {
+			if !errors.Is(err, errCountingGame) {
+				t.Errorf("Step at %d: got: %v, want: %v", i, err, errCountingGame)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Step at %d: got: %v, want: nil", i, err)
+		}
+	}
+	if got, want := g.count, n; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+type funcGame struct {
+	update func() error
+}
+
+func (g *funcGame) Update() error {
+	return g.update()
+}
+
+func (g *funcGame) Draw(screen *ebiten.Image) {
+}
+
+func (g *funcGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func TestTestLoopKey(t *testing.T) {
+	var pressed, justPressed, justReleased bool
+	l := ebiten.NewTestLoop(&funcGame{
+		update: func() error {
+			pressed = ebiten.IsKeyPressed(ebiten.KeyA)
+			justPressed = inpututil.IsKeyJustPressed(ebiten.KeyA)
+			justReleased = inpututil.IsKeyJustReleased(ebiten.KeyA)
+			return nil
+		},
+	})
+
+	testCases := []struct {
+		pressed      bool
+		wantPressed  bool
+		justPressed  bool
+		justReleased bool
+	}{
+		{pressed: true, wantPressed: true, justPressed: true, justReleased: false},
+		{pressed: true, wantPressed: true, justPressed: false, justReleased: false},
+		{pressed: false, wantPressed: false, justPressed: false, justReleased: true},
+		{pressed: false, wantPressed: false, justPressed: false, justReleased: false},
+	}
+	for i, tc := range testCases {
+		l.SetKeyPressed(ebiten.KeyA, tc.pressed)
+		if err := l.Step(); err != nil {
+			t.Fatal(err)
+		}
+		if pressed != tc.wantPressed {
+			t.Errorf("tick %d: IsKeyPressed: got: %v, want: %v", i, pressed, tc.wantPressed)
+		}
+		if justPressed != tc.justPressed {
+			t.Errorf("tick %d: IsKeyJustPressed: got: %v, want: %v", i, justPressed, tc.justPressed)
+		}
+		if justReleased != tc.justReleased {
+			t.Errorf("tick %d: IsKeyJustReleased: got: %v, want: %v", i, justReleased, tc.justReleased)
+		}
+	}
+}
+
+func TestTestLoopVirtualKey(t *testing.T) {
+	var shift, shiftLeft, shiftRight bool
+	l := ebiten.NewTestLoop(&funcGame{
+		update: func() error {
+			shift = ebiten.IsKeyPressed(ebiten.KeyShift)
+			shiftLeft = ebiten.IsKeyPressed(ebiten.KeyShiftLeft)
+			shiftRight = ebiten.IsKeyPressed(ebiten.KeyShiftRight)
+			return nil
+		},
+	})
+	defer func() {
+		l.SetKeyPressed(ebiten.KeyShift, false)
+		_ = l.Step()
+	}()
+
+	// KeyShift is regarded as KeyShiftLeft.
+	l.SetKeyPressed(ebiten.KeyShift, true)
+	if err := l.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if !shift || !shiftLeft || shiftRight {
+		t.Errorf("KeyShift, KeyShiftLeft, KeyShiftRight: got: %v, %v, %v, want: true, true, false", shift, shiftLeft, shiftRight)
+	}
+
+	l.SetKeyPressed(ebiten.KeyShift, false)
+	if err := l.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if shift || shiftLeft || shiftRight {
+		t.Errorf("KeyShift, KeyShiftLeft, KeyShiftRight: got: %v, %v, %v, want: false, false, false", shift, shiftLeft, shiftRight)
+	}
+}
+
+func TestTestLoopMouse(t *testing.T) {
+	var pressed bool
+	var x, y int
+	l := ebiten.NewTestLoop(&funcGame{
+		update: func() error {
+			pressed = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+			x, y = ebiten.CursorPosition()
+			return nil
+		},
+	})
+	defer func() {
+		l.SetMouseButtonPressed(ebiten.MouseButtonLeft, false)
+		_ = l.Step()
+	}()
+
+	l.SetMouseButtonPressed(ebiten.MouseButtonLeft, true)
+	l.SetCursorPosition(10, 20)
+	if err := l.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if !pressed {
+		t.Errorf("IsMouseButtonPressed: got: false, want: true")
+	}
+	if x != 10 || y != 20 {
+		t.Errorf("CursorPosition: got: (%d, %d), want: (10, 20)", x, y)
+	}
+
+	// The states are kept in the next tick.
+	if err := l.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if !pressed {
+		t.Errorf("IsMouseButtonPressed: got: false, want: true")
+	}
+	if x != 10 || y != 20 {
+		t.Errorf("CursorPosition: got: (%d, %d), want: (10, 20)", x, y)
+	}
+}
+
+func TestTestLoopWheelAndInputChars(t *testing.T) {
+	var wheelX, wheelY float64
+	var chars []rune
+	l := ebiten.NewTestLoop(&funcGame{
+		update: func() error {
+			wheelX, wheelY = ebiten.Wheel()
+			chars = ebiten.AppendInputChars(chars[:0])
+			return nil
+		},
+	})
+
+	l.AddWheel(1, 2)
+	l.AddWheel(0.5, 0)
+	l.AppendInputChars('a', 'b')
+	l.AppendInputChars('c')
+	if err := l.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if wheelX != 1.5 || wheelY != 2 {
+		t.Errorf("Wheel: got: (%f, %f), want: (1.5, 2)", wheelX, wheelY)
+	}
+	if got, want := string(chars), "abc"; got != want {
+		t.Errorf("AppendInputChars: got: %q, want: %q", got, want)
+	}
+
+	// The wheel and the input characters are reset after the tick.
+	if err := l.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if wheelX != 0 || wheelY != 0 {
+		t.Errorf("Wheel: got: (%f, %f), want: (0, 0)", wheelX, wheelY)
+	}
+	if got, want := string(chars), ""; got != want {
+		t.Errorf("AppendInputChars: got: %q, want: %q", got, want)
+	}
+}
@@ -16,7 +16,9 @@ package text
 
 import (
 	"math"
+	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/internal/hook"
@@ -40,14 +42,42 @@ func init() {
 type glyphImageCacheEntry struct {
 	image *ebiten.Image
 	atime int64
+	bytes int
 }
 
+// glyphImageCache is a cache of glyph images.
+//
+// glyphImageCache must be created by newGlyphImageCache.
+// The entries are registered to theGlyphImageCaches so that the total size of all the caches can be limited.
+// The entries are unregistered when glyphImageCache is GCed.
 type glyphImageCache[Key comparable] struct {
+	entries *glyphImageCacheEntries[Key]
+}
+
+func newGlyphImageCache[Key comparable]() *glyphImageCache[Key] {
+	e := &glyphImageCacheEntries[Key]{}
+	theGlyphImageCaches.add(e)
+	c := &glyphImageCache[Key]{
+		entries: e,
+	}
+	runtime.SetFinalizer(c, func(c *glyphImageCache[Key]) {
+		theGlyphImageCaches.remove(c.entries)
+	})
+	return c
+}
+
+func (g *glyphImageCache[Key]) getOrCreate(face Face, key Key, create func() *ebiten.Image) *ebiten.Image {
+	img := g.entries.getOrCreate(face, key, create)
+	theGlyphImageCaches.shrinkIfNeeded()
+	return img
+}
+
+type glyphImageCacheEntries[Key comparable] struct {
 	cache map[Key]*glyphImageCacheEntry
 	m     sync.Mutex
 }
 
-func (g *glyphImageCache[Key]) getOrCreate(face Face, key Key, create func() *ebiten.Image) *ebiten.Image {
+func (g *glyphImageCacheEntries[Key]) getOrCreate(face Face, key Key, create func() *ebiten.Image) *ebiten.Image {
 	g.m.Lock()
 	defer g.m.Unlock()
 
@@ -67,12 +97,15 @@ func (g *glyphImageCache[Key]) getOrCreate(face Face, key Key, create func() *eb
 	}
 	if img != nil {
 		e.atime = now()
+		e.bytes = 4 * img.Bounds().Dx() * img.Bounds().Dy()
 	} else {
 		// If the glyph image is nil, the entry doesn't have to be removed.
 		// Keep this until the face is GCed.
 		e.atime = infTime
 	}
 	g.cache[key] = e
+	theGlyphImageCaches.entryCount.Add(1)
+	theGlyphImageCaches.bytes.Add(int64(e.bytes))
 
 	// Clean up old entries.
 
@@ -87,9 +120,137 @@ func (g *glyphImageCache[Key]) getOrCreate(face Face, key Key, create func() *eb
 			if e.atime >= now()-60 {
 				continue
 			}
-			delete(g.cache, key)
+			g.deleteEntry(key, e)
 		}
 	}
 
 	return img
 }
+
+// deleteEntry deletes the entry for the key.
+// deleteEntry must be called with the lock held.
+func (g *glyphImageCacheEntries[Key]) deleteEntry(key Key, e *glyphImageCacheEntry) {
+	delete(g.cache, key)
+	theGlyphImageCaches.entryCount.Add(-1)
+	theGlyphImageCaches.bytes.Add(-int64(e.bytes))
+}
+
+// oldestAtime returns the access time of the least recently used entry with an image.
+// oldestAtime returns infTime if there is no such entry.
+func (g *glyphImageCacheEntries[Key]) oldestAtime() int64 {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	atime := int64(infTime)
+	for _, e := range g.cache {
+		if e.bytes == 0 {
+			continue
+		}
+		if atime > e.atime {
+			atime = e.atime
+		}
+	}
+	return atime
+}
+
+// evictOldest removes the least recently used entry with an image.
+func (g *glyphImageCacheEntries[Key]) evictOldest() {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	var oldestKey Key
+	var oldest *glyphImageCacheEntry
+	for key, e := range g.cache {
+		if e.bytes == 0 {
+			continue
+		}
+		if oldest == nil || oldest.atime > e.atime {
+			oldestKey = key
+			oldest = e
+		}
+	}
+	if oldest == nil {
+		return
+	}
+	g.deleteEntry(oldestKey, oldest)
+}
+
+// clear removes all the entries.
+func (g *glyphImageCacheEntries[Key]) clear() {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	for key, e := range g.cache {
+		g.deleteEntry(key, e)
+	}
+}
+
+type evictableGlyphImageCache interface {
+	oldestAtime() int64
+	evictOldest()
+	clear()
+}
+
+// theGlyphImageCaches is the registry of all the glyph image caches.
+var theGlyphImageCaches glyphImageCaches
+
+type glyphImageCaches struct {
+	caches map[evictableGlyphImageCache]struct{}
+
+	entryCount atomic.Int64
+	bytes      atomic.Int64
+	limit      atomic.Int64
+
+	// m is the mutex for caches.
+	// m must be locked before a lock of each cache is locked, not after.
+	m sync.Mutex
+}
+
+func (g *glyphImageCaches) add(cache evictableGlyphImageCache) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.caches == nil {
+		g.caches = map[evictableGlyphImageCache]struct{}{}
+	}
+	g.caches[cache] = struct{}{}
+}
+
+func (g *glyphImageCaches) remove(cache evictableGlyphImageCache) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	delete(g.caches, cache)
+	cache.clear()
+}
+
+func (g *glyphImageCaches) setLimit(bytes int) {
+	g.limit.Store(int64(bytes))
+	g.shrinkIfNeeded()
+}
+
+// shrinkIfNeeded removes the least recently used glyph images across all the caches until the total size is within the limit.
+func (g *glyphImageCaches) shrinkIfNeeded() {
+	limit := g.limit.Load()
+	if limit <= 0 || g.bytes.Load() <= limit {
+		return
+	}
+
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	for g.bytes.Load() > limit {
+		var oldest evictableGlyphImageCache
+		oldestAtime := int64(infTime)
+		for c := range g.caches {
+			if atime := c.oldestAtime(); oldestAtime > atime {
+				oldest = c
+				oldestAtime = atime
+			}
+		}
+		if oldest == nil {
+			break
+		}
+		oldest.evictOldest()
+	}
+}
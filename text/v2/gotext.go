@@ -58,6 +58,15 @@ type GoTextFace struct {
 	// If this is empty, the script is guessed from the specified language.
 	Script language.Script
 
+	// UprightInVertical indicates whether all the glyphs are rendered upright with a vertical direction.
+	//
+	// By default, with a vertical direction, glyphs of scripts usually written horizontally like Latin are rotated by 90 degrees clockwise,
+	// based on the Unicode vertical orientation property (UAX #50).
+	// If UprightInVertical is true, such glyphs are not rotated and are laid out with the vertical metrics.
+	//
+	// UprightInVertical is ignored with a horizontal direction.
+	UprightInVertical bool
+
 	variations []font.Variation
 	features   []shaping.FontFeature
 
@@ -246,6 +255,7 @@ func (g *GoTextFace) outputCacheKey(text string) goTextOutputCacheKey {
 		script:     g.Script.String(),
 		variations: g.ensureVariationsString(),
 		features:   g.ensureFeaturesString(),
+		upright:    g.UprightInVertical,
 	}
 }
 
@@ -346,6 +356,7 @@ func (g *GoTextFace) glyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Im
 		xoffset:    subpixelOffset.X,
 		yoffset:    subpixelOffset.Y,
 		variations: g.ensureVariationsString(),
+		sideways:   glyph.sideways,
 	}
 	img := g.Source.getOrCreateGlyphImage(g, key, func() *ebiten.Image {
 		return segmentsToImage(glyph.scaledSegments, subpixelOffset, b)
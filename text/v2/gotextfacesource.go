@@ -38,6 +38,7 @@ type goTextOutputCacheKey struct {
 	script     string
 	variations string
 	features   string
+	upright    bool
 }
 
 type glyph struct {
@@ -46,6 +47,7 @@ type glyph struct {
 	endIndex       int
 	scaledSegments []api.Segment
 	bounds         fixed.Rectangle26_6
+	sideways       bool
 }
 
 type goTextOutputCacheValue struct {
@@ -59,6 +61,7 @@ type goTextGlyphImageCacheKey struct {
 	xoffset    fixed.Int26_6
 	yoffset    fixed.Int26_6
 	variations string
+	sideways   bool
 }
 
 // GoTextFaceSource is a source of a GoTextFace. This can be shared by multiple GoTextFace objects.
@@ -204,6 +207,12 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) ([]shaping.Outpu
 		}
 	}
 
+	if face.UprightInVertical && !face.direction().isHorizontal() {
+		for i := range inputs {
+			inputs[i].Direction.SetSideways(false)
+		}
+	}
+
 	outputs := make([]shaping.Output, len(inputs))
 	var gs []glyph
 	for i, input := range inputs {
@@ -251,6 +260,7 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) ([]shaping.Outpu
 				endIndex:       indices[gl.ClusterIndex+gl.RuneCount],
 				scaledSegments: scaledSegs,
 				bounds:         segmentsToBounds(scaledSegs),
+				sideways:       out.Direction.IsSideways(),
 			})
 		}
 	}
@@ -287,7 +297,7 @@ func (g *GoTextFaceSource) getOrCreateGlyphImage(goTextFace *GoTextFace, key goT
 		g.glyphImageCache = map[float64]*glyphImageCache[goTextGlyphImageCacheKey]{}
 	}
 	if _, ok := g.glyphImageCache[goTextFace.Size]; !ok {
-		g.glyphImageCache[goTextFace.Size] = &glyphImageCache[goTextGlyphImageCacheKey]{}
+		g.glyphImageCache[goTextFace.Size] = newGlyphImageCache[goTextGlyphImageCacheKey]()
 	}
 	return g.glyphImageCache[goTextFace.Size].getOrCreate(goTextFace, key, create)
 }
@@ -43,7 +43,7 @@ type goXFaceGlyphImageCacheKey struct {
 type GoXFace struct {
 	f *faceWithCache
 
-	glyphImageCache glyphImageCache[goXFaceGlyphImageCacheKey]
+	glyphImageCache *glyphImageCache[goXFaceGlyphImageCacheKey]
 
 	addr *GoXFace
 }
@@ -54,6 +54,7 @@ func NewGoXFace(face font.Face) *GoXFace {
 		f: &faceWithCache{
 			f: face,
 		},
+		glyphImageCache: newGlyphImageCache[goXFaceGlyphImageCacheKey](),
 	}
 	s.addr = s
 	return s
@@ -15,6 +15,7 @@
 package text
 
 import (
+	"image/color"
 	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -122,6 +123,71 @@ func Draw(dst *ebiten.Image, text string, face Face, options *DrawOptions) {
 	}
 }
 
+// Span represents a run of a text with a color for DrawSpans.
+type Span struct {
+	// Text is the text of the span.
+	Text string
+
+	// Color is the color of the span.
+	// Color scales the color of the glyphs in addition to DrawOptions.ColorScale.
+	//
+	// If Color is nil, the color is not scaled.
+	Color color.Color
+}
+
+// DrawSpans draws given spans of texts on a given destination image dst.
+// face is the font for text rendering.
+//
+// The texts of the spans are laid out as one contiguous text, and then each glyph is rendered with the color of its span.
+// Thus, kerning and shaping across the spans are the same as when Draw is called with the concatenated text.
+// If a glyph covers multiple spans like a ligature, the glyph is rendered with the color of the span where the glyph starts.
+//
+// For the details of the other arguments, see Draw function.
+//
+// DrawSpans is concurrent-safe.
+func DrawSpans(dst *ebiten.Image, spans []Span, face Face, options *DrawOptions) {
+	var layoutOp LayoutOptions
+	var drawOp ebiten.DrawImageOptions
+
+	if options != nil {
+		layoutOp = options.LayoutOptions
+		drawOp = options.DrawImageOptions
+	}
+
+	geoM := drawOp.GeoM
+	colorScale := drawOp.ColorScale
+
+	var sb strings.Builder
+	ends := make([]int, len(spans))
+	for i, s := range spans {
+		sb.WriteString(s.Text)
+		ends[i] = sb.Len()
+	}
+
+	var spanIndex int
+	for _, g := range AppendGlyphs(nil, sb.String(), face, &layoutOp) {
+		if g.Image == nil {
+			continue
+		}
+		// Glyphs are not always in the order of the text, e.g. for a right-to-left text.
+		for spanIndex > 0 && g.StartIndexInBytes < ends[spanIndex-1] {
+			spanIndex--
+		}
+		for spanIndex < len(spans)-1 && g.StartIndexInBytes >= ends[spanIndex] {
+			spanIndex++
+		}
+
+		drawOp.GeoM.Reset()
+		drawOp.GeoM.Translate(g.X, g.Y)
+		drawOp.GeoM.Concat(geoM)
+		drawOp.ColorScale = colorScale
+		if clr := spans[spanIndex].Color; clr != nil {
+			drawOp.ColorScale.ScaleWithColor(clr)
+		}
+		dst.DrawImage(g.Image, &drawOp)
+	}
+}
+
 // AppendGlyphs appends glyphs to the given slice and returns a slice.
 //
 // AppendGlyphs is a low-level API, and you can use AppendGlyphs to have more control than Draw.
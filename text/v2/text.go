@@ -19,6 +19,7 @@ package text
 
 import (
 	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/image/math/fixed"
 
@@ -217,6 +218,81 @@ func Measure(text string, face Face, lineSpacingInPixels float64) (width, height
 	return secondary, primary
 }
 
+// WrapText splits the text into lines so that each line's advance is within maxWidth.
+//
+// WrapText measures the lines with the face's actual advances, and breaks the lines at spaces greedily.
+// Spaces at the line breaks are removed.
+// The '\n' newline character always breaks a line.
+// A word that doesn't fit in one line is broken at a rune boundary.
+// Every line has at least one rune even if maxWidth is too small.
+//
+// The width is measured in the primary direction, that is, horizontally for a horizontal-direction face
+// and vertically for a vertical-direction face.
+//
+// The result lines joined with '\n' can be passed to Draw to render the wrapped text.
+//
+// WrapText is concurrent-safe.
+func WrapText(text string, face Face, maxWidth float64) []string {
+	var lines []string
+	for t := text; ; {
+		paragraph, rest, found := strings.Cut(t, "\n")
+		lines = appendWrappedLines(lines, paragraph, face, maxWidth)
+		if !found {
+			break
+		}
+		t = rest
+	}
+	return lines
+}
+
+func appendWrappedLines(lines []string, paragraph string, face Face, maxWidth float64) []string {
+	var line string
+	var wrapped bool
+	for i, word := range strings.Split(paragraph, " ") {
+		switch {
+		case i == 0:
+			line = word
+		case wrapped && line == "" && word == "":
+			// Skip consecutive spaces at the start of a wrapped line.
+			continue
+		case wrapped && line == "":
+			line = word
+		case face.advance(line+" "+word) <= maxWidth:
+			line += " " + word
+		default:
+			lines = append(lines, strings.TrimRight(line, " "))
+			line = word
+			wrapped = true
+		}
+
+		// Break a too long word.
+		for face.advance(line) > maxWidth {
+			n := fittingPrefixLength(line, face, maxWidth)
+			if n == len(line) {
+				break
+			}
+			lines = append(lines, line[:n])
+			line = line[n:]
+			wrapped = true
+		}
+	}
+	return append(lines, line)
+}
+
+// fittingPrefixLength returns the length in bytes of the longest prefix of the text whose advance is within maxWidth.
+// fittingPrefixLength returns the length of at least one rune for a non-empty text.
+func fittingPrefixLength(text string, face Face, maxWidth float64) int {
+	var n int
+	for n < len(text) {
+		_, size := utf8.DecodeRuneInString(text[n:])
+		if n > 0 && face.advance(text[:n+size]) > maxWidth {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
 // CacheGlyphs pre-caches the glyphs for the given text and the given font face into the cache.
 //
 // CacheGlyphs doesn't treat multiple lines.
@@ -254,3 +330,25 @@ func CacheGlyphs(text string, face Face) {
 		}
 	}
 }
+
+// CacheStats returns the number of the cached glyph images and their approximate size in bytes.
+//
+// The glyph images are cached by each face, and CacheStats returns the total of all the faces.
+//
+// CacheStats is concurrent-safe.
+func CacheStats() (entryCount int, bytes int) {
+	return int(theGlyphImageCaches.entryCount.Load()), int(theGlyphImageCaches.bytes.Load())
+}
+
+// SetCacheLimit sets the limit of the total size in bytes of the cached glyph images of all the faces.
+//
+// When the total size exceeds the limit, the least recently used glyph images are removed from the caches.
+// A removed glyph image is created again when the glyph is rendered next time.
+// This is useful to bound the memory usage when many faces or sizes are used temporarily.
+//
+// If bytes is 0 or negative, the total size is not limited. By default, the total size is not limited.
+//
+// SetCacheLimit is concurrent-safe.
+func SetCacheLimit(bytes int) {
+	theGlyphImageCaches.setLimit(bytes)
+}
@@ -15,17 +15,24 @@
 package text_test
 
 import (
+	"bytes"
 	"image"
 	"image/color"
+	"math"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/hajimehoshi/bitmapfont/v3"
 	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
 	"golang.org/x/image/math/fixed"
+	"golang.org/x/text/language"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
 	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
@@ -371,3 +378,279 @@ func TestDrawOptionsNotModified(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestDrawSpans(t *testing.T) {
+	f := text.NewGoXFace(bitmapfont.Face)
+	const str = "Hello, World!"
+
+	want := ebiten.NewImage(120, 30)
+	text.Draw(want, str, f, nil)
+
+	// The spans without colors should be rendered in the same way as the concatenated text.
+	got := ebiten.NewImage(120, 30)
+	text.DrawSpans(got, []text.Span{
+		{Text: str[:3]},
+		{Text: str[3:8]},
+		{Text: str[8:]},
+	}, f, nil)
+
+	w, h := want.Bounds().Dx(), want.Bounds().Dy()
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if got, want := got.At(i, j), want.At(i, j); got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawSpansKern(t *testing.T) {
+	f := text.NewGoXFace(&testGoXFace{})
+	dst := ebiten.NewImage(testGoXFaceSize*2, testGoXFaceSize)
+
+	// With testGoXFace, 'b' is rendered at the previous position as 0xff.
+	// The kerning across the spans must be applied as Draw with "ab" does.
+	text.DrawSpans(dst, []text.Span{
+		{Text: "a", Color: color.RGBA{R: 0xff, A: 0xff}},
+		{Text: "b", Color: color.RGBA{B: 0xff, A: 0xff}},
+	}, f, nil)
+	for j := 0; j < testGoXFaceSize; j++ {
+		for i := 0; i < testGoXFaceSize*2; i++ {
+			got := dst.At(i, j)
+			want := color.RGBA{B: 0xff, A: 0xff}
+			if i >= testGoXFaceSize {
+				want = color.RGBA{}
+			}
+			if got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+// proportionalGoXFace is a face whose advances differ by runes.
+type proportionalGoXFace struct{}
+
+func (f *proportionalGoXFace) advance(r rune) fixed.Int26_6 {
+	switch r {
+	case 'i':
+		return fixed.I(2)
+	case 'm':
+		return fixed.I(10)
+	case ' ':
+		return fixed.I(3)
+	}
+	return fixed.I(6)
+}
+
+func (f *proportionalGoXFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	return image.Rectangle{}, image.NewAlpha(image.Rectangle{}), image.Point{}, f.advance(r), true
+}
+
+func (f *proportionalGoXFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	return fixed.Rectangle26_6{}, f.advance(r), true
+}
+
+func (f *proportionalGoXFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	return f.advance(r), true
+}
+
+func (f *proportionalGoXFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	return 0
+}
+
+func (f *proportionalGoXFace) Close() error {
+	return nil
+}
+
+func (f *proportionalGoXFace) Metrics() font.Metrics {
+	return font.Metrics{
+		Height:     fixed.I(10),
+		Ascent:     fixed.I(8),
+		Descent:    fixed.I(2),
+		CaretSlope: image.Pt(0, 1),
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	f := text.NewGoXFace(&proportionalGoXFace{})
+
+	testCases := []struct {
+		Text     string
+		MaxWidth float64
+		Want     []string
+	}{
+		// "iiiiiiii" has more runes than "mm", but both have the same width.
+		{
+			Text:     "iiiiiiii mm",
+			MaxWidth: 20,
+			Want:     []string{"iiiiiiii", "mm"},
+		},
+		{
+			Text:     "ii ii mm",
+			MaxWidth: 20,
+			Want:     []string{"ii ii", "mm"},
+		},
+		{
+			Text:     "mm ii ii",
+			MaxWidth: 20,
+			Want:     []string{"mm", "ii ii"},
+		},
+		// A too long word is broken.
+		{
+			Text:     "iiii mmmm",
+			MaxWidth: 30,
+			Want:     []string{"iiii", "mmm", "m"},
+		},
+		// Spaces at the line breaks are removed.
+		{
+			Text:     "a  b  c",
+			MaxWidth: 14,
+			Want:     []string{"a", "b", "c"},
+		},
+		// Newlines are kept.
+		{
+			Text:     "ab\n\ncd ef",
+			MaxWidth: 14,
+			Want:     []string{"ab", "", "cd", "ef"},
+		},
+		{
+			Text:     "  leading spaces",
+			MaxWidth: 1000,
+			Want:     []string{"  leading spaces"},
+		},
+		// Each line has at least one rune.
+		{
+			Text:     "mmm",
+			MaxWidth: 0,
+			Want:     []string{"m", "m", "m"},
+		},
+		{
+			Text:     "",
+			MaxWidth: 10,
+			Want:     []string{""},
+		},
+	}
+	for _, tc := range testCases {
+		got := text.WrapText(tc.Text, f, tc.MaxWidth)
+		if !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("WrapText(%q, %v): got: %q, want: %q", tc.Text, tc.MaxWidth, got, tc.Want)
+		}
+		for _, line := range got {
+			if a := text.Advance(line, f); a > tc.MaxWidth && len([]rune(line)) > 1 {
+				t.Errorf("WrapText(%q, %v): the line %q is too long: %v", tc.Text, tc.MaxWidth, line, a)
+			}
+		}
+	}
+}
+
+func TestVerticalMixedScripts(t *testing.T) {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		size = 24
+		str  = "あHelloあ"
+	)
+
+	for _, upright := range []bool{false, true} {
+		f := &text.GoTextFace{
+			Source:            src,
+			Direction:         text.DirectionTopToBottomAndRightToLeft,
+			Size:              size,
+			Language:          language.Japanese,
+			UprightInVertical: upright,
+		}
+
+		// A CJK glyph has the advance of 1em in both orientations.
+		if got, want := text.Advance("あ", f), float64(size); got != want {
+			t.Errorf("upright: %t: Advance(%q): got: %v, want: %v", upright, "あ", got, want)
+		}
+
+		// Upright Latin glyphs use the vertical advances, which are 1em for this font.
+		// Sideways Latin glyphs use the horizontal advances, which are narrower.
+		latinAdvance := text.Advance("Hello", f)
+		if upright {
+			if got, want := latinAdvance, float64(5*size); got != want {
+				t.Errorf("upright: %t: Advance(%q): got: %v, want: %v", upright, "Hello", got, want)
+			}
+		} else {
+			if latinAdvance <= 0 || latinAdvance >= 5*size {
+				t.Errorf("upright: %t: Advance(%q): got: %v, want: (0, %v)", upright, "Hello", latinAdvance, 5*size)
+			}
+		}
+
+		glyphs := text.AppendGlyphs(nil, str, f, nil)
+
+		// The column advances downward, and the second 'あ' comes after the Latin run.
+		var first, last, l *text.Glyph
+		for i := range glyphs {
+			g := &glyphs[i]
+			switch g.StartIndexInBytes {
+			case 0:
+				first = g
+			case len(str) - len("あ"):
+				last = g
+			case strings.Index(str, "l"):
+				l = g
+			}
+		}
+		if first == nil || last == nil || l == nil {
+			t.Fatalf("upright: %t: glyphs not found", upright)
+		}
+		if got, want := last.Y-first.Y, float64(size)+latinAdvance; math.Abs(got-want) > 1 {
+			t.Errorf("upright: %t: the distance between the CJK glyphs: got: %v, want: %v", upright, got, want)
+		}
+		if got, want := last.X, first.X; got != want {
+			t.Errorf("upright: %t: the X of the CJK glyphs: got: %v, want: %v", upright, got, want)
+		}
+
+		// 'l' is tall in the upright orientation, and wide in the sideways orientation.
+		w, h := l.Image.Bounds().Dx(), l.Image.Bounds().Dy()
+		if upright && w >= h {
+			t.Errorf("upright: %t: the glyph 'l' must be tall: %d x %d", upright, w, h)
+		}
+		if !upright && w <= h {
+			t.Errorf("upright: %t: the glyph 'l' must be wide: %d x %d", upright, w, h)
+		}
+	}
+}
+
+func TestCacheLimit(t *testing.T) {
+	const limit = 16 * 1024
+	text.SetCacheLimit(limit)
+	defer text.SetCacheLimit(0)
+
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	xf := text.NewGoXFace(bitmapfont.Face)
+
+	// Render texts with various sizes like damage numbers in a game.
+	for i := 0; i < 200; i++ {
+		str := strconv.Itoa(i * 7919)
+		f := &text.GoTextFace{
+			Source: src,
+			Size:   float64(10 + i%40),
+		}
+		text.AppendGlyphs(nil, str, f, nil)
+		text.AppendGlyphs(nil, str, xf, nil)
+		if _, b := text.CacheStats(); b > limit {
+			t.Fatalf("i: %d: the cache size %d exceeds the limit %d", i, b, limit)
+		}
+	}
+
+	n, b := text.CacheStats()
+	if n == 0 || b == 0 {
+		t.Errorf("the cache must not be empty: entries: %d, bytes: %d", n, b)
+	}
+
+	// Shrinking the limit must remove glyph images immediately.
+	text.SetCacheLimit(limit / 4)
+	if _, b := text.CacheStats(); b > limit/4 {
+		t.Errorf("the cache size %d exceeds the limit %d", b, limit/4)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tilemap provides a renderer that draws a tile map with one draw call.
+package tilemap
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Flags represents how a tile is flipped or rotated.
+//
+// The flags follow the convention of the Tiled map editor.
+// A diagonal flip is applied first, and then the horizontal and vertical flips are applied.
+// For example, FlipDiagonal|FlipHorizontal rotates a tile by 90 degrees clockwise.
+type Flags uint8
+
+const (
+	// FlipHorizontal flips a tile horizontally.
+	FlipHorizontal Flags = 1 << iota
+
+	// FlipVertical flips a tile vertically.
+	FlipVertical
+
+	// FlipDiagonal flips a tile along the diagonal from the top-left corner to the bottom-right corner.
+	FlipDiagonal
+)
+
+// Tile represents a tile in a tile map.
+type Tile struct {
+	// Index is the index of the tile in the tileset, counted from left to right, and then top to bottom.
+	// A negative index means that there is no tile.
+	Index int
+
+	// Flags is how the tile is flipped or rotated.
+	Flags Flags
+}
+
+// DrawOptions represents options for (*TileRenderer).Draw.
+type DrawOptions struct {
+	// GeoM is a geometry matrix to transform the world coordinates, where a tile has its tile size, to the destination.
+	// For example, use (*camera.Camera).Matrix.
+	//
+	// The default (zero) value is identity, which draws the top-left tile at (0, 0).
+	GeoM ebiten.GeoM
+
+	// VisibleBounds is the rectangle in the world coordinates to draw.
+	// Tiles outside of VisibleBounds are culled.
+	// For example, use (*camera.Camera).WorldBounds.
+	//
+	// The default (empty) value means that all the tiles are drawn.
+	VisibleBounds image.Rectangle
+
+	// ColorScale is a scale of color.
+	//
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Filter is a type of texture filter.
+	//
+	// The default (zero) value is ebiten.FilterNearest.
+	Filter ebiten.Filter
+}
+
+// TileRenderer draws a tile map with a tileset.
+//
+// As opposed to calling DrawImage for each tile, a TileRenderer draws all the visible tiles with one DrawTriangles32 call.
+// The buffers for the vertices are reused across Draw calls.
+type TileRenderer struct {
+	tileset    *ebiten.Image
+	tileWidth  int
+	tileHeight int
+	columns    int
+	count      int
+
+	vertices []ebiten.Vertex
+	indices  []uint32
+}
+
+// NewTileRenderer creates a new TileRenderer with the tileset and the tile size.
+//
+// tileset can be a sub-image of a bigger atlas.
+//
+// NewTileRenderer panics if the tile size is not positive, or no tile fits in tileset.
+func NewTileRenderer(tileset *ebiten.Image, tileWidth, tileHeight int) *TileRenderer {
+	if tileWidth <= 0 || tileHeight <= 0 {
+		panic(fmt.Sprintf("tilemap: tile size must be positive but (%d, %d)", tileWidth, tileHeight))
+	}
+	columns := tileset.Bounds().Dx() / tileWidth
+	rows := tileset.Bounds().Dy() / tileHeight
+	if columns == 0 || rows == 0 {
+		panic(fmt.Sprintf("tilemap: no tile of (%d, %d) fits in the tileset", tileWidth, tileHeight))
+	}
+	return &TileRenderer{
+		tileset:    tileset,
+		tileWidth:  tileWidth,
+		tileHeight: tileHeight,
+		columns:    columns,
+		count:      columns * rows,
+	}
+}
+
+// Draw draws the tiles on dst.
+//
+// tiles is a grid of tiles indexed as tiles[y][x]. The rows can have different lengths.
+// The tile at tiles[y][x] is placed at (x * tileWidth, y * tileHeight) in the world coordinates.
+//
+// If options is nil, the default setting is used.
+//
+// Draw panics if a tile index is not less than the number of the tiles in the tileset.
+func (t *TileRenderer) Draw(dst *ebiten.Image, tiles [][]Tile, options *DrawOptions) {
+	if options == nil {
+		options = &DrawOptions{}
+	}
+
+	// Calculate the range of the visible tiles.
+	x0, y0 := 0, 0
+	x1, y1 := 0, len(tiles)
+	bounded := !options.VisibleBounds.Empty()
+	if b := options.VisibleBounds; bounded {
+		x0 = floorDiv(b.Min.X, t.tileWidth)
+		y0 = floorDiv(b.Min.Y, t.tileHeight)
+		x1 = floorDiv(b.Max.X-1, t.tileWidth) + 1
+		y1 = floorDiv(b.Max.Y-1, t.tileHeight) + 1
+	}
+	if y0 < 0 {
+		y0 = 0
+	}
+	if y1 > len(tiles) {
+		y1 = len(tiles)
+	}
+	if x0 < 0 {
+		x0 = 0
+	}
+
+	cr, cg, cb, ca := options.ColorScale.R(), options.ColorScale.G(), options.ColorScale.B(), options.ColorScale.A()
+	sb := t.tileset.Bounds()
+
+	t.vertices = t.vertices[:0]
+	t.indices = t.indices[:0]
+	for j := y0; j < y1; j++ {
+		row := tiles[j]
+		xEnd := len(row)
+		if bounded && x1 < xEnd {
+			xEnd = x1
+		}
+		for i := x0; i < xEnd; i++ {
+			tile := row[i]
+			if tile.Index < 0 {
+				continue
+			}
+			if tile.Index >= t.count {
+				panic(fmt.Sprintf("tilemap: tile index must be less than %d but %d", t.count, tile.Index))
+			}
+
+			sx := sb.Min.X + (tile.Index%t.columns)*t.tileWidth
+			sy := sb.Min.Y + (tile.Index/t.columns)*t.tileHeight
+
+			idx := uint32(len(t.vertices))
+			for k := 0; k < 4; k++ {
+				// cx and cy are the corner in the tile in [0, 1].
+				cx, cy := k%2, k/2
+
+				// Find the corner in the source for the corner in the destination.
+				u, v := cx, cy
+				if tile.Flags&FlipHorizontal != 0 {
+					u = 1 - u
+				}
+				if tile.Flags&FlipVertical != 0 {
+					v = 1 - v
+				}
+				if tile.Flags&FlipDiagonal != 0 {
+					u, v = v, u
+				}
+
+				dx, dy := options.GeoM.Apply(float64((i+cx)*t.tileWidth), float64((j+cy)*t.tileHeight))
+				t.vertices = append(t.vertices, ebiten.Vertex{
+					DstX:   float32(dx),
+					DstY:   float32(dy),
+					SrcX:   float32(sx + u*t.tileWidth),
+					SrcY:   float32(sy + v*t.tileHeight),
+					ColorR: cr,
+					ColorG: cg,
+					ColorB: cb,
+					ColorA: ca,
+				})
+			}
+			t.indices = append(t.indices, idx, idx+1, idx+2, idx+1, idx+3, idx+2)
+		}
+	}
+
+	if len(t.indices) == 0 {
+		return
+	}
+
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	op.Filter = options.Filter
+	dst.DrawTriangles32(t.vertices, t.indices, t.tileset, op)
+}
+
+func floorDiv(x, y int) int {
+	d := x / y
+	if x%y != 0 && (x < 0) != (y < 0) {
+		d--
+	}
+	return d
+}
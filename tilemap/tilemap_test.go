@@ -0,0 +1,190 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+	"github.com/hajimehoshi/ebiten/v2/tilemap"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+// newTileset returns a tileset with two 2x2 tiles.
+// Each pixel has a distinct color: R is the tile index, G is x and B is y in the tile.
+func newTileset() *ebiten.Image {
+	const w, h = 4, 2
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			c := tilesetColor(i/2, i%2, j)
+			idx := 4 * (j*w + i)
+			pix[idx] = c.R
+			pix[idx+1] = c.G
+			pix[idx+2] = c.B
+			pix[idx+3] = c.A
+		}
+	}
+	img := ebiten.NewImage(w, h)
+	img.WritePixels(pix)
+	return img
+}
+
+func tilesetColor(index, x, y int) color.RGBA {
+	return color.RGBA{R: byte(index) * 0x80, G: byte(x) * 0x80, B: byte(y) * 0x80, A: 0xff}
+}
+
+func TestTileRenderer(t *testing.T) {
+	r := tilemap.NewTileRenderer(newTileset(), 2, 2)
+	tiles := [][]tilemap.Tile{
+		{{Index: 0}, {Index: 1}},
+		{{Index: -1}, {Index: 1, Flags: tilemap.FlipDiagonal | tilemap.FlipHorizontal}},
+	}
+
+	dst := ebiten.NewImage(4, 4)
+	r.Draw(dst, tiles, nil)
+
+	for _, c := range []struct {
+		X    int
+		Y    int
+		Want color.RGBA
+	}{
+		{0, 0, tilesetColor(0, 0, 0)},
+		{1, 1, tilesetColor(0, 1, 1)},
+		{2, 0, tilesetColor(1, 0, 0)},
+		{3, 1, tilesetColor(1, 1, 1)},
+		// An empty tile.
+		{0, 2, color.RGBA{}},
+		// A tile rotated by 90 degrees clockwise: the top-left is the bottom-left of the source.
+		{2, 2, tilesetColor(1, 0, 1)},
+		{3, 2, tilesetColor(1, 0, 0)},
+		{2, 3, tilesetColor(1, 1, 1)},
+		{3, 3, tilesetColor(1, 1, 0)},
+	} {
+		if got := dst.At(c.X, c.Y); got != c.Want {
+			t.Errorf("dst.At(%d, %d): got: %v, want: %v", c.X, c.Y, got, c.Want)
+		}
+	}
+}
+
+func TestTileRendererVisibleBounds(t *testing.T) {
+	r := tilemap.NewTileRenderer(newTileset(), 2, 2)
+	tiles := [][]tilemap.Tile{
+		{{Index: 0}, {Index: 0}, {Index: 0}},
+		{{Index: 0}, {Index: 0}, {Index: 0}},
+	}
+
+	dst := ebiten.NewImage(8, 8)
+	op := &tilemap.DrawOptions{}
+	op.GeoM.Translate(1, 1)
+	// Only the tile at (1, 0) overlaps with the bounds.
+	op.VisibleBounds = image.Rect(2, 0, 4, 1)
+	r.Draw(dst, tiles, op)
+
+	for j := 0; j < 8; j++ {
+		for i := 0; i < 8; i++ {
+			want := color.RGBA{}
+			if i >= 3 && i < 5 && j >= 1 && j < 3 {
+				want = tilesetColor(0, i-3, j-1)
+			}
+			if got := dst.At(i, j); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestTileRendererVisibleBoundsOutside(t *testing.T) {
+	r := tilemap.NewTileRenderer(newTileset(), 2, 2)
+	tiles := [][]tilemap.Tile{
+		{{Index: 0}, {Index: 0}, {Index: 0}},
+		{{Index: 0}, {Index: 0}, {Index: 0}},
+	}
+
+	for _, b := range []image.Rectangle{
+		// Entirely left of the map.
+		image.Rect(-8, 0, -4, 4),
+		// Entirely above the map.
+		image.Rect(0, -8, 6, -4),
+		// Entirely left of and above the map.
+		image.Rect(-8, -8, -4, -4),
+	} {
+		dst := ebiten.NewImage(8, 8)
+		op := &tilemap.DrawOptions{}
+		op.VisibleBounds = b
+		r.Draw(dst, tiles, op)
+
+		for j := 0; j < 8; j++ {
+			for i := 0; i < 8; i++ {
+				if got, want := dst.At(i, j), (color.RGBA{}); got != want {
+					t.Errorf("bounds: %v, dst.At(%d, %d): got: %v, want: %v", b, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+const benchmarkMapSize = 200
+
+func newBenchmarkTiles() [][]tilemap.Tile {
+	tiles := make([][]tilemap.Tile, benchmarkMapSize)
+	for j := range tiles {
+		tiles[j] = make([]tilemap.Tile, benchmarkMapSize)
+		for i := range tiles[j] {
+			tiles[j][i] = tilemap.Tile{Index: (i + j) % 2}
+		}
+	}
+	return tiles
+}
+
+func BenchmarkTileRenderer(b *testing.B) {
+	tileset := newTileset()
+	tiles := newBenchmarkTiles()
+	dst := ebiten.NewImage(benchmarkMapSize*2, benchmarkMapSize*2)
+	r := tilemap.NewTileRenderer(tileset, 2, 2)
+	for i := 0; i < b.N; i++ {
+		r.Draw(dst, tiles, nil)
+		// Flush the commands.
+		_ = dst.At(0, 0)
+	}
+}
+
+func BenchmarkDrawImagePerTile(b *testing.B) {
+	tileset := newTileset()
+	tiles := newBenchmarkTiles()
+	dst := ebiten.NewImage(benchmarkMapSize*2, benchmarkMapSize*2)
+	subImages := []*ebiten.Image{
+		tileset.SubImage(image.Rect(0, 0, 2, 2)).(*ebiten.Image),
+		tileset.SubImage(image.Rect(2, 0, 4, 2)).(*ebiten.Image),
+	}
+	op := &ebiten.DrawImageOptions{}
+	for i := 0; i < b.N; i++ {
+		for y, row := range tiles {
+			for x, tile := range row {
+				op.GeoM.Reset()
+				op.GeoM.Translate(float64(x*2), float64(y*2))
+				dst.DrawImage(subImages[tile.Index], op)
+			}
+		}
+		// Flush the commands.
+		_ = dst.At(0, 0)
+	}
+}
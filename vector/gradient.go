@@ -0,0 +1,203 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DrawLinearGradient fills the whole dst with a linear gradient from (x0, y0) to (x1, y1).
+//
+// The color is clr0 at (x0, y0) and clr1 at (x1, y1), and is interpolated linearly in between.
+// Outside the two points, the color is extended with clr0 or clr1, as CSS's linear-gradient does.
+// The gradient is rendered over the existing content of dst with the regular alpha blending.
+//
+// If (x0, y0) and (x1, y1) are the same, dst is filled with clr1.
+func DrawLinearGradient(dst *ebiten.Image, x0, y0, x1, y1 float32, clr0, clr1 color.Color) {
+	b := dst.Bounds()
+	dx, dy := x1-x0, y1-y0
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		DrawFilledRect(dst, float32(b.Min.X), float32(b.Min.Y), float32(b.Dx()), float32(b.Dy()), clr1, false)
+		return
+	}
+
+	// Represent the bounds in the gradient coordinate (u, v).
+	// u is 0 at (x0, y0) and 1 at (x1, y1). v is along the perpendicular direction (-dy, dx).
+	umin, vmin := float32(math.Inf(1)), float32(math.Inf(1))
+	umax, vmax := float32(math.Inf(-1)), float32(math.Inf(-1))
+	for _, p := range [][2]float32{
+		{float32(b.Min.X), float32(b.Min.Y)},
+		{float32(b.Max.X), float32(b.Min.Y)},
+		{float32(b.Min.X), float32(b.Max.Y)},
+		{float32(b.Max.X), float32(b.Max.Y)},
+	} {
+		px, py := p[0]-x0, p[1]-y0
+		u := (px*dx + py*dy) / l2
+		v := (-px*dy + py*dx) / l2
+		umin = min32(umin, u)
+		umax = max32(umax, u)
+		vmin = min32(vmin, v)
+		vmax = max32(vmax, v)
+	}
+
+	var vs []ebiten.Vertex
+	var is []uint16
+	appendBand := func(u0, u1 float32, clr0, clr1 color.Color) {
+		if u0 >= u1 {
+			return
+		}
+		idx := uint16(len(vs))
+		for _, c := range []struct {
+			u, v float32
+			clr  color.Color
+		}{
+			{u0, vmin, clr0},
+			{u1, vmin, clr1},
+			{u0, vmax, clr0},
+			{u1, vmax, clr1},
+		} {
+			vs = append(vs, ebiten.Vertex{
+				DstX: x0 + c.u*dx - c.v*dy,
+				DstY: y0 + c.u*dy + c.v*dx,
+			})
+			setVertexColor(&vs[len(vs)-1], c.clr)
+		}
+		is = append(is, idx, idx+1, idx+2, idx+1, idx+2, idx+3)
+	}
+	appendBand(umin, min32(0, umax), clr0, clr0)
+	appendBand(max32(0, umin), min32(1, umax), lerpColor(clr0, clr1, max32(0, umin)), lerpColor(clr0, clr1, min32(1, umax)))
+	appendBand(max32(1, umin), umax, clr1, clr1)
+
+	drawColoredVertices(dst, vs, is)
+}
+
+// DrawRadialGradient fills the whole dst with a radial gradient centered at (cx, cy) with the radius r.
+//
+// The color is clr0 at the center and clr1 on the circle, and is interpolated linearly in between.
+// Outside the circle, the color is clr1.
+// The gradient is rendered over the existing content of dst with the regular alpha blending.
+//
+// If r is not positive, dst is filled with clr1.
+func DrawRadialGradient(dst *ebiten.Image, cx, cy, r float32, clr0, clr1 color.Color) {
+	b := dst.Bounds()
+	if r <= 0 {
+		DrawFilledRect(dst, float32(b.Min.X), float32(b.Min.Y), float32(b.Dx()), float32(b.Dy()), clr1, false)
+		return
+	}
+
+	// The number of the segments is determined so that each segment is short enough.
+	n := int(math.Ceil(2 * math.Pi * float64(r) / 4))
+	if n < 16 {
+		n = 16
+	}
+	// Keep the number of the vertices within the range of uint16 indices.
+	if n > 1024 {
+		n = 1024
+	}
+
+	// The outer polygon must cover the farthest corner of the bounds.
+	var far float32
+	for _, p := range [][2]float32{
+		{float32(b.Min.X), float32(b.Min.Y)},
+		{float32(b.Max.X), float32(b.Min.Y)},
+		{float32(b.Min.X), float32(b.Max.Y)},
+		{float32(b.Max.X), float32(b.Max.Y)},
+	} {
+		far = max32(far, float32(math.Hypot(float64(p[0]-cx), float64(p[1]-cy))))
+	}
+	outerR := max32(r, far) / float32(math.Cos(math.Pi/float64(n)))
+
+	vs := make([]ebiten.Vertex, 0, 2*n+1)
+	is := make([]uint16, 0, 9*n)
+
+	vs = append(vs, ebiten.Vertex{DstX: cx, DstY: cy})
+	setVertexColor(&vs[0], clr0)
+	for i := 0; i < n; i++ {
+		theta := 2 * math.Pi * float64(i) / float64(n)
+		sin, cos := math.Sincos(theta)
+		vs = append(vs, ebiten.Vertex{
+			DstX: cx + r*float32(cos),
+			DstY: cy + r*float32(sin),
+		}, ebiten.Vertex{
+			DstX: cx + outerR*float32(cos),
+			DstY: cy + outerR*float32(sin),
+		})
+		setVertexColor(&vs[len(vs)-2], clr1)
+		setVertexColor(&vs[len(vs)-1], clr1)
+	}
+	for i := 0; i < n; i++ {
+		inner0 := uint16(1 + 2*i)
+		outer0 := inner0 + 1
+		inner1 := uint16(1 + 2*((i+1)%n))
+		outer1 := inner1 + 1
+		is = append(is, 0, inner0, inner1)
+		is = append(is, inner0, outer0, inner1, inner1, outer0, outer1)
+	}
+
+	drawColoredVertices(dst, vs, is)
+}
+
+func setVertexColor(v *ebiten.Vertex, clr color.Color) {
+	r, g, b, a := clr.RGBA()
+	v.ColorR = float32(r) / 0xffff
+	v.ColorG = float32(g) / 0xffff
+	v.ColorB = float32(b) / 0xffff
+	v.ColorA = float32(a) / 0xffff
+}
+
+// lerpColor returns the color between clr0 and clr1 at the rate t in the premultiplied alpha space.
+// This is the same as the interpolation of vertex colors.
+func lerpColor(clr0, clr1 color.Color, t float32) color.Color {
+	r0, g0, b0, a0 := clr0.RGBA()
+	r1, g1, b1, a1 := clr1.RGBA()
+	lerp := func(x, y uint32) uint16 {
+		return uint16(math.Round(float64((1-t)*float32(x) + t*float32(y))))
+	}
+	return color.RGBA64{
+		R: lerp(r0, r1),
+		G: lerp(g0, g1),
+		B: lerp(b0, b1),
+		A: lerp(a0, a1),
+	}
+}
+
+func drawColoredVertices(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16) {
+	for i := range vs {
+		vs[i].SrcX = 1
+		vs[i].SrcY = 1
+	}
+
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	dst.DrawTriangles(vs, is, whiteSubImage, op)
+}
+
+func min32(x, y float32) float32 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+func max32(x, y float32) float32 {
+	if x > y {
+		return x
+	}
+	return y
+}
@@ -46,6 +46,15 @@ type point struct {
 type subpath struct {
 	points []point
 	closed bool
+
+	// dotDirection is the unit direction of a zero-length dash, which has only one point.
+	// dotDirection is used to render the line caps of the dash.
+	dotDirection point
+}
+
+// isDot reports whether the subpath is a zero-length dash.
+func (s *subpath) isDot() bool {
+	return len(s.points) == 1 && (s.dotDirection.x != 0 || s.dotDirection.y != 0)
 }
 
 func (s *subpath) currentPosition() (point, bool) {
@@ -92,6 +101,33 @@ func (s *subpath) close() {
 // Path represents a collection of path subpathments.
 type Path struct {
 	subpaths []*subpath
+
+	flatteningTolerance float32
+}
+
+// defaultFlatteningTolerance is the default flattening tolerance in pixels.
+const defaultFlatteningTolerance = 0.5
+
+// SetFlatteningTolerance sets the tolerance in pixels to approximate curves with line segments.
+//
+// Curves added by QuadTo, CubicTo, ArcTo, and Arc are subdivided adaptively
+// so that the distance between a curve and its line segments is less than the tolerance.
+// A smaller tolerance makes curves smoother with more vertices, and a bigger tolerance makes curves coarser with fewer vertices.
+// The tolerance is applied to curves added after SetFlatteningTolerance is called.
+//
+// If tolerance is not positive, the default tolerance 0.5 is used.
+func (p *Path) SetFlatteningTolerance(tolerance float32) {
+	if tolerance <= 0 {
+		tolerance = 0
+	}
+	p.flatteningTolerance = tolerance
+}
+
+func (p *Path) tolerance() float32 {
+	if p.flatteningTolerance == 0 {
+		return defaultFlatteningTolerance
+	}
+	return p.flatteningTolerance
 }
 
 // MoveTo starts a new subpath with the given position (x, y) without adding a subpath,
@@ -170,7 +206,7 @@ func (p *Path) quadTo(p1, p2 point, level int) {
 	if !ok {
 		p0 = p1
 	}
-	if isPointCloseToSegment(p1, p0, p2, 0.5) {
+	if isPointCloseToSegment(p1, p0, p2, p.tolerance()) {
 		p.LineTo(p2.x, p2.y)
 		return
 	}
@@ -206,7 +242,7 @@ func (p *Path) cubicTo(p1, p2, p3 point, level int) {
 	if !ok {
 		p0 = p1
 	}
-	if isPointCloseToSegment(p1, p0, p3, 0.5) && isPointCloseToSegment(p2, p0, p3, 0.5) {
+	if tol := p.tolerance(); isPointCloseToSegment(p1, p0, p3, tol) && isPointCloseToSegment(p2, p0, p3, tol) {
 		p.LineTo(p3.x, p3.y)
 		return
 	}
@@ -471,6 +507,25 @@ type StrokeOptions struct {
 	//
 	// The default (zero) value is 0.
 	MiterLimit float32
+
+	// Dash is the pattern of dashes and gaps in pixels.
+	// The values are the lengths of a dash, a gap, a dash, a gap, and so on.
+	// If the number of the values is odd, the values are repeated to make it even,
+	// as SVG's stroke-dasharray does.
+	//
+	// The pattern continues across the segments of a subpath, and restarts at every subpath.
+	// Each dash is rendered with LineCap.
+	//
+	// If Dash is empty, has a negative value, or sums to 0, a solid stroke is rendered.
+	// A solid stroke is also rendered if the pattern is too fine for the path, e.g. the lengths are far below a pixel.
+	//
+	// The default (zero) value is nil.
+	Dash []float32
+
+	// DashPhase is the offset in pixels of the start of the dash pattern.
+	//
+	// The default (zero) value is 0.
+	DashPhase float32
 }
 
 // AppendVerticesAndIndicesForStroke appends vertices and indices to render a stroke of this path and returns them.
@@ -486,7 +541,17 @@ func (p *Path) AppendVerticesAndIndicesForStroke(vertices []ebiten.Vertex, indic
 		return vertices, indices
 	}
 
+	if dashed, ok := p.dashed(op.Dash, op.DashPhase); ok {
+		solidOp := *op
+		solidOp.Dash = nil
+		return dashed.AppendVerticesAndIndicesForStroke(vertices, indices, &solidOp)
+	}
+
 	for _, subpath := range p.subpaths {
+		if subpath.isDot() {
+			vertices, indices = appendVerticesAndIndicesForDot(vertices, indices, subpath, op)
+			continue
+		}
 		if subpath.pointCount() < 2 {
 			continue
 		}
@@ -683,3 +748,154 @@ func (p *Path) AppendVerticesAndIndicesForStroke(vertices []ebiten.Vertex, indic
 
 	return vertices, indices
 }
+
+// appendVerticesAndIndicesForDot appends the line caps of a zero-length dash.
+// A zero-length dash with butt caps is not rendered.
+func appendVerticesAndIndicesForDot(vertices []ebiten.Vertex, indices []uint16, dot *subpath, op *StrokeOptions) ([]ebiten.Vertex, []uint16) {
+	c := dot.points[0]
+	switch op.LineCap {
+	case LineCapButt:
+		// Do nothing.
+
+	case LineCapRound:
+		var arc Path
+		arc.MoveTo(c.x+op.Width/2, c.y)
+		arc.Arc(c.x, c.y, op.Width/2, 0, 2*math.Pi, Clockwise)
+		vertices, indices = arc.AppendVerticesAndIndicesForFilling(vertices, indices)
+
+	case LineCapSquare:
+		// (dx, dy) is along the dash, and (nx, ny) is perpendicular to the dash.
+		dx, dy := dot.dotDirection.x*op.Width/2, dot.dotDirection.y*op.Width/2
+		nx, ny := dy, -dx
+
+		var quad Path
+		quad.MoveTo(c.x-dx+nx, c.y-dy+ny)
+		quad.LineTo(c.x+dx+nx, c.y+dy+ny)
+		quad.LineTo(c.x+dx-nx, c.y+dy-ny)
+		quad.LineTo(c.x-dx-nx, c.y-dy-ny)
+		vertices, indices = quad.AppendVerticesAndIndicesForFilling(vertices, indices)
+	}
+	return vertices, indices
+}
+
+// maxDashIterations is the maximum number of the entries of a dash pattern that dashed can walk through for a path.
+const maxDashIterations = 1 << 16
+
+// dashed returns a new path that consists of dashes along p.
+// dashed returns false if the dash pattern is not valid, or if the pattern is too fine for the path.
+func (p *Path) dashed(pattern32 []float32, phase32 float32) (*Path, bool) {
+	if len(pattern32) == 0 {
+		return nil, false
+	}
+	// Use float64 for the positions. With float32, adding a short length to a long position might not change the position.
+	pattern := make([]float64, 0, 2*len(pattern32))
+	for _, v := range pattern32 {
+		pattern = append(pattern, float64(v))
+	}
+	if len(pattern)%2 == 1 {
+		pattern = append(pattern, pattern...)
+	}
+	var total float64
+	for _, v := range pattern {
+		if v < 0 {
+			return nil, false
+		}
+		total += v
+	}
+	if total == 0 {
+		return nil, false
+	}
+
+	// Find the initial position in the pattern.
+	phase := math.Mod(float64(phase32), total)
+	if phase < 0 {
+		phase += total
+	}
+	var startIdx int
+	// A zero-length dash at the phase is kept to render its line caps.
+	for phase > pattern[startIdx] || (phase == pattern[startIdx] && pattern[startIdx] > 0) {
+		phase -= pattern[startIdx]
+		startIdx = (startIdx + 1) % len(pattern)
+	}
+	startRemaining := pattern[startIdx] - phase
+
+	var iterations int
+	var dashed Path
+	for _, sp := range p.subpaths {
+		if sp.pointCount() < 2 {
+			continue
+		}
+
+		idx := startIdx
+		remaining := startRemaining
+		// Even indices are dashes and odd indices are gaps.
+		on := idx%2 == 0
+
+		var dashes []*subpath
+		var current *subpath
+		if on {
+			current = &subpath{points: []point{sp.points[0]}}
+		}
+		startsAtStart := on
+
+		for i := 0; i < sp.pointCount()-1; i++ {
+			p0, p1 := sp.points[i], sp.points[i+1]
+			dx, dy := float64(p1.x-p0.x), float64(p1.y-p0.y)
+			l := math.Hypot(dx, dy)
+			if l == 0 {
+				continue
+			}
+			// A dash ending with only one point is a zero-length dash. Record the direction for its line caps.
+			dir := point{x: float32(dx / l), y: float32(dy / l)}
+			var pos float64
+			for l-pos > remaining {
+				// A too fine pattern would make too many dashes. Render a solid stroke instead.
+				iterations++
+				if iterations > maxDashIterations {
+					return nil, false
+				}
+				pos += remaining
+				pt := point{x: p0.x + float32(dx*pos/l), y: p0.y + float32(dy*pos/l)}
+				if on {
+					current.appendPoint(pt)
+					current.dotDirection = dir
+					dashes = append(dashes, current)
+					current = nil
+				} else {
+					current = &subpath{points: []point{pt}}
+				}
+				on = !on
+				idx = (idx + 1) % len(pattern)
+				remaining = pattern[idx]
+			}
+			remaining -= l - pos
+			if on {
+				current.appendPoint(p1)
+				current.dotDirection = dir
+			}
+		}
+		if current != nil {
+			switch {
+			case sp.closed && startsAtStart && len(dashes) == 0:
+				// One dash covers the whole closed subpath. Keep the subpath closed to render joins instead of caps.
+				current.closed = true
+				dashes = append(dashes, current)
+			case sp.closed && startsAtStart:
+				// In a closed subpath, connect the last dash and the first dash at the start point.
+				first := dashes[0]
+				current.points = append(current.points, first.points[1:]...)
+				dashes[0] = current
+			default:
+				dashes = append(dashes, current)
+			}
+		}
+
+		for _, d := range dashes {
+			if d.pointCount() < 2 && !d.isDot() {
+				continue
+			}
+			dashed.subpaths = append(dashed.subpaths, d)
+		}
+	}
+	return &dashed, true
+}
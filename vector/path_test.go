@@ -0,0 +1,347 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+func TestStrokeDash(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Dash      []float32
+		DashPhase float32
+		Count     int
+	}{
+		{
+			Name:  "solid",
+			Dash:  nil,
+			Count: 1,
+		},
+		{
+			Name:  "even",
+			Dash:  []float32{10, 10},
+			Count: 5,
+		},
+		{
+			Name:  "odd",
+			Dash:  []float32{10},
+			Count: 5,
+		},
+		{
+			Name:  "uneven",
+			Dash:  []float32{30, 20},
+			Count: 2,
+		},
+		{
+			Name:      "phase",
+			Dash:      []float32{10, 10},
+			DashPhase: 5,
+			Count:     6,
+		},
+		{
+			Name:      "negative phase",
+			Dash:      []float32{10, 10},
+			DashPhase: -5,
+			Count:     5,
+		},
+		{
+			Name:  "negative value",
+			Dash:  []float32{-10, 10},
+			Count: 1,
+		},
+		{
+			Name:  "zero sum",
+			Dash:  []float32{0, 0},
+			Count: 1,
+		},
+		{
+			Name:  "too fine",
+			Dash:  []float32{1e-6, 1e-6},
+			Count: 1,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var p vector.Path
+			p.MoveTo(0, 0)
+			p.LineTo(100, 0)
+			vs, is := p.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{
+				Width:     2,
+				Dash:      tc.Dash,
+				DashPhase: tc.DashPhase,
+			})
+			// Each dash on a straight line is one rectangle with butt caps.
+			if got, want := len(vs), 4*tc.Count; got != want {
+				t.Errorf("len(vertices): got: %d, want: %d", got, want)
+			}
+			if got, want := len(is), 6*tc.Count; got != want {
+				t.Errorf("len(indices): got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
+func TestStrokeDashLongSegment(t *testing.T) {
+	// With float32 positions, adding the gap 1 to a position bigger than 2^24 doesn't change the position.
+	var p vector.Path
+	p.MoveTo(0, 0)
+	p.LineTo(1<<25, 0)
+	vs, _ := p.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{
+		Width: 2,
+		Dash:  []float32{1 << 23, 1},
+	})
+	if got, want := len(vs), 4*4; got != want {
+		t.Errorf("len(vertices): got: %d, want: %d", got, want)
+	}
+}
+
+func TestStrokeDashClosedPath(t *testing.T) {
+	var p vector.Path
+	p.MoveTo(0, 0)
+	p.LineTo(40, 0)
+	p.LineTo(40, 40)
+	p.LineTo(0, 40)
+	p.Close()
+
+	// The dash across the start point of the closed path must be one dash.
+	var want vector.Path
+	for _, d := range [][][2]float32{
+		{{0, 5}, {0, 0}, {5, 0}},
+		{{15, 0}, {25, 0}},
+		{{35, 0}, {40, 0}, {40, 5}},
+		{{40, 15}, {40, 25}},
+		{{40, 35}, {40, 40}, {35, 40}},
+		{{25, 40}, {15, 40}},
+		{{5, 40}, {0, 40}, {0, 35}},
+		{{0, 25}, {0, 15}},
+	} {
+		want.MoveTo(d[0][0], d[0][1])
+		for _, pt := range d[1:] {
+			want.LineTo(pt[0], pt[1])
+		}
+	}
+
+	op := &vector.StrokeOptions{
+		Width:     2,
+		LineJoin:  vector.LineJoinRound,
+		Dash:      []float32{10, 10},
+		DashPhase: 5,
+	}
+	gotVs, gotIs := p.AppendVerticesAndIndicesForStroke(nil, nil, op)
+	op.Dash = nil
+	wantVs, wantIs := want.AppendVerticesAndIndicesForStroke(nil, nil, op)
+	if len(gotVs) != len(wantVs) || len(gotIs) != len(wantIs) {
+		t.Fatalf("got: %d vertices and %d indices, want: %d vertices and %d indices", len(gotVs), len(gotIs), len(wantVs), len(wantIs))
+	}
+	for i := range gotVs {
+		if gotVs[i] != wantVs[i] {
+			t.Errorf("vertices[%d]: got: %v, want: %v", i, gotVs[i], wantVs[i])
+		}
+	}
+	for i := range gotIs {
+		if gotIs[i] != wantIs[i] {
+			t.Errorf("indices[%d]: got: %d, want: %d", i, gotIs[i], wantIs[i])
+		}
+	}
+}
+
+func TestStrokeDashZeroLength(t *testing.T) {
+	const width = 2
+
+	// countFilling returns the numbers of vertices and indices to fill the path made by f.
+	countFilling := func(f func(p *vector.Path)) (int, int) {
+		var p vector.Path
+		f(&p)
+		vs, is := p.AppendVerticesAndIndicesForFilling(nil, nil)
+		return len(vs), len(is)
+	}
+	circleVs, circleIs := countFilling(func(p *vector.Path) {
+		p.MoveTo(width/2, 0)
+		p.Arc(0, 0, width/2, 0, 2*math.Pi, vector.Clockwise)
+	})
+	squareVs, squareIs := countFilling(func(p *vector.Path) {
+		p.MoveTo(0, 0)
+		p.LineTo(1, 0)
+		p.LineTo(1, 1)
+		p.LineTo(0, 1)
+	})
+
+	testCases := []struct {
+		Name     string
+		LineCap  vector.LineCap
+		Vertices int
+		Indices  int
+		MinX     float32
+		MaxX     float32
+	}{
+		{
+			Name:    "butt",
+			LineCap: vector.LineCapButt,
+		},
+		{
+			Name:     "round",
+			LineCap:  vector.LineCapRound,
+			Vertices: 10 * circleVs,
+			Indices:  10 * circleIs,
+			MinX:     -width / 2,
+			MaxX:     90 + width/2,
+		},
+		{
+			Name:     "square",
+			LineCap:  vector.LineCapSquare,
+			Vertices: 10 * squareVs,
+			Indices:  10 * squareIs,
+			MinX:     -width / 2,
+			MaxX:     90 + width/2,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var p vector.Path
+			p.MoveTo(0, 0)
+			p.LineTo(100, 0)
+			vs, is := p.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{
+				Width:   width,
+				LineCap: tc.LineCap,
+				Dash:    []float32{0, 10},
+			})
+			// Each zero-length dash at 0, 10, ..., 90 is rendered only with its line caps.
+			if got, want := len(vs), tc.Vertices; got != want {
+				t.Errorf("len(vertices): got: %d, want: %d", got, want)
+			}
+			if got, want := len(is), tc.Indices; got != want {
+				t.Errorf("len(indices): got: %d, want: %d", got, want)
+			}
+			if len(vs) == 0 {
+				return
+			}
+			minX, maxX := vs[0].DstX, vs[0].DstX
+			for _, v := range vs {
+				if minX > v.DstX {
+					minX = v.DstX
+				}
+				if maxX < v.DstX {
+					maxX = v.DstX
+				}
+			}
+			if math.Abs(float64(minX-tc.MinX)) > 1e-3 || math.Abs(float64(maxX-tc.MaxX)) > 1e-3 {
+				t.Errorf("x range: got: [%f, %f], want: [%f, %f]", minX, maxX, tc.MinX, tc.MaxX)
+			}
+		})
+	}
+}
+
+func TestStrokeDashClosedPathCoveredByOneDash(t *testing.T) {
+	var p vector.Path
+	p.MoveTo(0, 0)
+	p.LineTo(40, 0)
+	p.LineTo(40, 40)
+	p.LineTo(0, 40)
+	p.Close()
+
+	// The dash is longer than the perimeter. The result must be the same as the solid stroke with joins.
+	op := &vector.StrokeOptions{
+		Width:      2,
+		LineCap:    vector.LineCapRound,
+		LineJoin:   vector.LineJoinMiter,
+		MiterLimit: 10,
+		Dash:       []float32{200, 10},
+	}
+	gotVs, gotIs := p.AppendVerticesAndIndicesForStroke(nil, nil, op)
+	op.Dash = nil
+	wantVs, wantIs := p.AppendVerticesAndIndicesForStroke(nil, nil, op)
+	if len(gotVs) != len(wantVs) || len(gotIs) != len(wantIs) {
+		t.Fatalf("got: %d vertices and %d indices, want: %d vertices and %d indices", len(gotVs), len(gotIs), len(wantVs), len(wantIs))
+	}
+	for i := range gotVs {
+		if gotVs[i] != wantVs[i] {
+			t.Errorf("vertices[%d]: got: %v, want: %v", i, gotVs[i], wantVs[i])
+		}
+	}
+	for i := range gotIs {
+		if gotIs[i] != wantIs[i] {
+			t.Errorf("indices[%d]: got: %d, want: %d", i, gotIs[i], wantIs[i])
+		}
+	}
+}
+
+func TestFlatteningTolerance(t *testing.T) {
+	const (
+		cx = 0
+		cy = 0
+	)
+	circle := func(radius, tolerance float32) []ebiten.Vertex {
+		var p vector.Path
+		p.SetFlatteningTolerance(tolerance)
+		p.Arc(cx, cy, radius, 0, 2*math.Pi, vector.Clockwise)
+		p.Close()
+		vs, _ := p.AppendVerticesAndIndicesForFilling(nil, nil)
+		return vs
+	}
+
+	radii := []float32{10, 100, 1000}
+	tolerances := []float32{2, 0.5, 0.1}
+
+	for _, r := range radii {
+		for _, tol := range tolerances {
+			vs := circle(r, tol)
+			// The middle point of each segment must be close enough to the circle.
+			// The Bézier approximation of an arc itself has a tiny error proportional to the radius.
+			allow := float64(tol) + 3e-4*float64(r)
+			for i := range vs {
+				v0, v1 := vs[i], vs[(i+1)%len(vs)]
+				mx, my := (v0.DstX+v1.DstX)/2, (v0.DstY+v1.DstY)/2
+				if d := float64(r) - math.Hypot(float64(mx-cx), float64(my-cy)); d > allow {
+					t.Errorf("radius: %v, tolerance: %v: the segment %d is too far from the circle: %v", r, tol, i, d)
+				}
+			}
+		}
+	}
+
+	// A smaller tolerance and a bigger radius should require more segments.
+	for _, r := range radii {
+		for i := 1; i < len(tolerances); i++ {
+			n0 := len(circle(r, tolerances[i-1]))
+			n1 := len(circle(r, tolerances[i]))
+			if n0 >= n1 {
+				t.Errorf("radius: %v: the number of the vertices for the tolerance %v (%d) must be less than for %v (%d)", r, tolerances[i-1], n0, tolerances[i], n1)
+			}
+		}
+	}
+	for _, tol := range tolerances {
+		for i := 1; i < len(radii); i++ {
+			n0 := len(circle(radii[i-1], tol))
+			n1 := len(circle(radii[i], tol))
+			if n0 >= n1 {
+				t.Errorf("tolerance: %v: the number of the vertices for the radius %v (%d) must be less than for %v (%d)", tol, radii[i-1], n0, radii[i], n1)
+			}
+		}
+	}
+
+	// The default tolerance is used for a non-positive value.
+	if got, want := len(circle(100, 0)), len(circle(100, 0.5)); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+	if got, want := len(circle(100, -1)), len(circle(100, 0.5)); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
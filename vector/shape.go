@@ -0,0 +1,119 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector
+
+import (
+	"math"
+)
+
+// AppendRoundedRectPath appends a closed subpath of a rounded rectangle to path.
+//
+// (x, y) is the upper-left position and (width, height) is the size of the rectangle.
+// radius is the radius of the corners, and is clamped to the half of the shorter side.
+// If radius is not positive, the corners are not rounded.
+//
+// The subpath is wound clockwise, and can be used for both filling and stroking.
+// The smoothness of the corners is controlled by Path.SetFlatteningTolerance.
+//
+// If width or height is not positive, AppendRoundedRectPath does nothing.
+func AppendRoundedRectPath(path *Path, x, y, width, height, radius float32) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	radius = min32(radius, min32(width, height)/2)
+	if radius <= 0 {
+		path.MoveTo(x, y)
+		path.LineTo(x+width, y)
+		path.LineTo(x+width, y+height)
+		path.LineTo(x, y+height)
+		path.Close()
+		return
+	}
+
+	path.MoveTo(x+radius, y)
+	path.Arc(x+width-radius, y+radius, radius, -math.Pi/2, 0, Clockwise)
+	path.Arc(x+width-radius, y+height-radius, radius, 0, math.Pi/2, Clockwise)
+	path.Arc(x+radius, y+height-radius, radius, math.Pi/2, math.Pi, Clockwise)
+	path.Arc(x+radius, y+radius, radius, math.Pi, 3*math.Pi/2, Clockwise)
+	path.Close()
+}
+
+// AppendCirclePath appends a closed subpath of a circle to path.
+//
+// (cx, cy) is the center and r is the radius of the circle.
+//
+// The subpath is wound clockwise, and can be used for both filling and stroking.
+// The smoothness of the circle is controlled by Path.SetFlatteningTolerance.
+//
+// If r is not positive, AppendCirclePath does nothing.
+func AppendCirclePath(path *Path, cx, cy, r float32) {
+	AppendEllipsePath(path, cx, cy, r, r)
+}
+
+// AppendEllipsePath appends a closed subpath of an axis-aligned ellipse to path.
+//
+// (cx, cy) is the center, and rx and ry are the horizontal and vertical radii of the ellipse.
+//
+// The subpath is wound clockwise, and can be used for both filling and stroking.
+// The smoothness of the ellipse is controlled by Path.SetFlatteningTolerance.
+//
+// If rx or ry is not positive, AppendEllipsePath does nothing.
+func AppendEllipsePath(path *Path, cx, cy, rx, ry float32) {
+	if rx <= 0 || ry <= 0 {
+		return
+	}
+
+	// Approximate each quarter of the ellipse with a cubic Bézier curve.
+	// See https://spencermortensen.com/articles/bezier-circle/.
+	const k = 0.5522847498
+	kx, ky := rx*k, ry*k
+
+	path.MoveTo(cx+rx, cy)
+	path.CubicTo(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	path.CubicTo(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	path.CubicTo(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	path.CubicTo(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	path.Close()
+}
+
+// AppendRegularPolygonPath appends a closed subpath of a regular polygon to path.
+//
+// (cx, cy) is the center and r is the radius of the circumscribed circle of the polygon.
+// n is the number of the sides.
+// rotation is the angle in radians of the first vertex from the positive X axis.
+// When rotation is 0, the first vertex is at (cx+r, cy).
+//
+// The subpath is wound clockwise, and can be used for both filling and stroking.
+// AppendRegularPolygonPath with a big n can be used as a circle with the explicit number of segments.
+//
+// If r is not positive or n is less than 3, AppendRegularPolygonPath does nothing.
+func AppendRegularPolygonPath(path *Path, cx, cy, r float32, n int, rotation float32) {
+	if r <= 0 || n < 3 {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		sin, cos := math.Sincos(float64(rotation) + 2*math.Pi*float64(i)/float64(n))
+		x := cx + r*float32(cos)
+		y := cy + r*float32(sin)
+		if i == 0 {
+			path.MoveTo(x, y)
+			continue
+		}
+		path.LineTo(x, y)
+	}
+	path.Close()
+}
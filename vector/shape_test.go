@@ -0,0 +1,162 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+func TestAppendShapePath(t *testing.T) {
+	testCases := []struct {
+		Name                   string
+		Append                 func(path *vector.Path)
+		MinX, MinY, MaxX, MaxY float32
+	}{
+		{
+			Name: "rounded rect",
+			Append: func(path *vector.Path) {
+				vector.AppendRoundedRectPath(path, 10, 20, 100, 50, 10)
+			},
+			MinX: 10,
+			MinY: 20,
+			MaxX: 110,
+			MaxY: 70,
+		},
+		{
+			Name: "rounded rect with a too big radius",
+			Append: func(path *vector.Path) {
+				vector.AppendRoundedRectPath(path, 10, 20, 100, 50, 100)
+			},
+			MinX: 10,
+			MinY: 20,
+			MaxX: 110,
+			MaxY: 70,
+		},
+		{
+			Name: "rect",
+			Append: func(path *vector.Path) {
+				vector.AppendRoundedRectPath(path, 10, 20, 100, 50, 0)
+			},
+			MinX: 10,
+			MinY: 20,
+			MaxX: 110,
+			MaxY: 70,
+		},
+		{
+			Name: "circle",
+			Append: func(path *vector.Path) {
+				vector.AppendCirclePath(path, 50, 60, 30)
+			},
+			MinX: 20,
+			MinY: 30,
+			MaxX: 80,
+			MaxY: 90,
+		},
+		{
+			Name: "ellipse",
+			Append: func(path *vector.Path) {
+				vector.AppendEllipsePath(path, 50, 60, 40, 20)
+			},
+			MinX: 10,
+			MinY: 40,
+			MaxX: 90,
+			MaxY: 80,
+		},
+		{
+			Name: "hexagon",
+			Append: func(path *vector.Path) {
+				vector.AppendRegularPolygonPath(path, 50, 60, 10, 6, 0)
+			},
+			MinX: 40,
+			MinY: 60 - 5*float32(math.Sqrt(3)),
+			MaxX: 60,
+			MaxY: 60 + 5*float32(math.Sqrt(3)),
+		},
+		{
+			Name: "square",
+			Append: func(path *vector.Path) {
+				vector.AppendRegularPolygonPath(path, 50, 60, 10, 4, math.Pi/4)
+			},
+			MinX: 50 - 5*float32(math.Sqrt(2)),
+			MinY: 60 - 5*float32(math.Sqrt(2)),
+			MaxX: 50 + 5*float32(math.Sqrt(2)),
+			MaxY: 60 + 5*float32(math.Sqrt(2)),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var path vector.Path
+			tc.Append(&path)
+
+			vs, _ := path.AppendVerticesAndIndicesForFilling(nil, nil)
+			if len(vs) == 0 {
+				t.Fatal("no vertices")
+			}
+			minX, minY := float32(math.Inf(1)), float32(math.Inf(1))
+			maxX, maxY := float32(math.Inf(-1)), float32(math.Inf(-1))
+			var area float32
+			for i, v := range vs {
+				minX = float32(math.Min(float64(minX), float64(v.DstX)))
+				minY = float32(math.Min(float64(minY), float64(v.DstY)))
+				maxX = float32(math.Max(float64(maxX), float64(v.DstX)))
+				maxY = float32(math.Max(float64(maxY), float64(v.DstY)))
+				next := vs[(i+1)%len(vs)]
+				area += v.DstX*next.DstY - next.DstX*v.DstY
+			}
+			const eps = 1e-2
+			if math.Abs(float64(minX-tc.MinX)) > eps || math.Abs(float64(minY-tc.MinY)) > eps || math.Abs(float64(maxX-tc.MaxX)) > eps || math.Abs(float64(maxY-tc.MaxY)) > eps {
+				t.Errorf("bounds: got: (%v, %v)-(%v, %v), want: (%v, %v)-(%v, %v)", minX, minY, maxX, maxY, tc.MinX, tc.MinY, tc.MaxX, tc.MaxY)
+			}
+			// The outline must be wound clockwise on the screen, where the Y axis points downward.
+			if area <= 0 {
+				t.Errorf("the outline must be wound clockwise: signed area: %v", area/2)
+			}
+
+			// A closed subpath doesn't have line caps, then the line cap must not affect the result.
+			buttVs, buttIs := path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{
+				Width:   2,
+				LineCap: vector.LineCapButt,
+			})
+			squareVs, squareIs := path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{
+				Width:   2,
+				LineCap: vector.LineCapSquare,
+			})
+			if len(buttVs) != len(squareVs) || len(buttIs) != len(squareIs) {
+				t.Errorf("the subpath must be closed")
+			}
+		})
+	}
+}
+
+func TestAppendShapePathEmpty(t *testing.T) {
+	for _, f := range []func(path *vector.Path){
+		func(path *vector.Path) { vector.AppendRoundedRectPath(path, 0, 0, 0, 10, 1) },
+		func(path *vector.Path) { vector.AppendRoundedRectPath(path, 0, 0, 10, -1, 1) },
+		func(path *vector.Path) { vector.AppendCirclePath(path, 0, 0, 0) },
+		func(path *vector.Path) { vector.AppendEllipsePath(path, 0, 0, 10, 0) },
+		func(path *vector.Path) { vector.AppendRegularPolygonPath(path, 0, 0, 10, 2, 0) },
+		func(path *vector.Path) { vector.AppendRegularPolygonPath(path, 0, 0, -1, 6, 0) },
+	} {
+		var path vector.Path
+		f(&path)
+		if vs, _ := path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{Width: 1}); len(vs) != 0 {
+			t.Errorf("got: %d vertices, want: 0", len(vs))
+		}
+	}
+}
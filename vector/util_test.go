@@ -16,6 +16,7 @@ package vector_test
 
 import (
 	"image/color"
+	"math"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -35,3 +36,41 @@ func TestLine0(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestLinearGradient(t *testing.T) {
+	const w, h = 256, 4
+	dst := ebiten.NewImage(w, h)
+	vector.DrawLinearGradient(dst, 64, 0, 192, 0, color.Black, color.White)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			var want byte
+			switch {
+			case i < 64:
+				want = 0
+			case i >= 192:
+				want = 0xff
+			default:
+				want = byte(math.Round((float64(i) + 0.5 - 64) / 128 * 0xff))
+			}
+			got := dst.At(i, j).(color.RGBA)
+			if d := int(got.R) - int(want); got.A != 0xff || d < -2 || d > 2 {
+				t.Errorf("dst.At(%d, %d): got: %v, want: R=G=B=%d, A=255", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestRadialGradient(t *testing.T) {
+	const w, h = 64, 64
+	dst := ebiten.NewImage(w, h)
+	vector.DrawRadialGradient(dst, 32, 32, 16, color.White, color.Black)
+	// The center of the pixel (32, 32) is (32.5, 32.5), which is slightly off from the center of the gradient.
+	if got := dst.At(32, 32).(color.RGBA); got.R < 0xf0 || got.A != 0xff {
+		t.Errorf("dst.At(32, 32): got: %v, want: almost white", got)
+	}
+	for _, p := range [][2]int{{0, 0}, {63, 0}, {0, 63}, {63, 63}, {32, 10}} {
+		if got, want := dst.At(p[0], p[1]).(color.RGBA), (color.RGBA{A: 0xff}); got != want {
+			t.Errorf("dst.At(%d, %d): got: %v, want: %v", p[0], p[1], got, want)
+		}
+	}
+}
@@ -57,20 +57,24 @@ func Vibrate(options *VibrateOptions) {
 // VibrateGamepadOptions represents the options for gamepad vibration.
 type VibrateGamepadOptions struct {
 	// Duration is the time duration of the effect.
+	// If Duration is 0 or negative, the current effect is stopped.
 	Duration time.Duration
 
 	// StrongMagnitude is the rumble intensity of a low-frequency rumble motor.
-	// The value is in between 0 and 1.
+	// The value is in between 0 and 1. A value out of the range is clamped.
 	StrongMagnitude float64
 
 	// WeakMagnitude is the rumble intensity of a high-frequency rumble motor.
-	// The value is in between 0 and 1.
+	// The value is in between 0 and 1. A value out of the range is clamped.
 	WeakMagnitude float64
 }
 
 // VibrateGamepad vibrates the specified gamepad with the specified options.
 //
-// VibrateGamepad works only on browsers and Nintendo Switch so far.
+// VibrateGamepad works only on browsers, Nintendo Switch, Xbox, and XInput gamepads on Windows so far.
+// Use IsGamepadVibrationSupported to check whether the gamepad can vibrate.
+//
+// A new call replaces the current effect.
 //
 // VibrateGamepad is concurrent-safe.
 func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
@@ -80,3 +84,16 @@ func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
 	}
 	g.Vibrate(options.Duration, options.StrongMagnitude, options.WeakMagnitude)
 }
+
+// IsGamepadVibrationSupported reports whether the specified gamepad can vibrate with VibrateGamepad.
+//
+// IsGamepadVibrationSupported returns false if the gamepad is not connected.
+//
+// IsGamepadVibrationSupported is concurrent-safe.
+func IsGamepadVibrationSupported(gamepadID GamepadID) bool {
+	g := gamepad.Get(gamepadID)
+	if g == nil {
+		return false
+	}
+	return g.IsVibrationSupported()
+}
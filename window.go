@@ -110,18 +110,18 @@ func SetWindowTitle(title string) {
 //
 // If len(iconImages) is 0, SetWindowIcon reverts the icon to the default one.
 //
-// For desktops, see the document of glfwSetWindowIcon of GLFW 3.2:
+// iconImages are candidates in different sizes. Good sizes include 16x16, 32x32, 48x48, and 256x256.
+// How an icon is selected depends on the platform:
 //
-//	This function sets the icon of the specified window.
-//	If passed an array of candidate images, those of or closest to the sizes
-//	desired by the system are selected.
-//	If no images are specified, the window reverts to its default icon.
+//   - Windows: For each of the small icon (the title bar) and the big icon (the taskbar and the task switcher),
+//     the smallest image that is not smaller than the size the system desires is selected,
+//     and is scaled down to the size with a box filter. If there is no such image, the largest image is selected.
+//     The desired sizes depend on the display scale. The images are selected only when SetWindowIcon is called,
+//     and are not selected again when the display scale changes later, e.g. by moving the window to another monitor.
+//   - Linux and other X11 platforms: All the images are passed to the window manager, which selects the images to use.
+//   - macOS: As macOS windows don't have icons, SetWindowIcon doesn't work. The Dock icon is taken from the application bundle.
 //
-//	The desired image sizes varies depending on platform and system settings.
-//	The selected images will be rescaled as needed.
-//	Good sizes include 16x16, 32x32 and 48x48.
-//
-// As macOS windows don't have icons, SetWindowIcon doesn't work on macOS.
+// To specify the small and the big icons explicitly, use SetWindowIconPair.
 //
 // SetWindowIcon doesn't work if the platform is not a desktop.
 //
@@ -130,6 +130,20 @@ func SetWindowIcon(iconImages []image.Image) {
 	ui.Get().Window().SetIcon(iconImages)
 }
 
+// SetWindowIconPair sets the small and the large icons of the game window explicitly.
+//
+// On Windows, small is used for the title bar, and large is used for the taskbar and the task switcher.
+// Each image is scaled down to the size the system desires if it is bigger than the size.
+// As with SetWindowIcon, the images are scaled only when SetWindowIconPair is called.
+// On Linux and other X11 platforms, the window manager selects the images to use from small and large.
+//
+// SetWindowIconPair doesn't work on macOS, or if the platform is not a desktop.
+//
+// SetWindowIconPair is concurrent-safe.
+func SetWindowIconPair(small, large image.Image) {
+	ui.Get().Window().SetIconPair(small, large)
+}
+
 // WindowPosition returns the window position.
 // The origin position is the upper-left corner of the current monitor.
 // The unit is device-independent pixels.
@@ -215,6 +229,8 @@ func SetWindowSizeLimits(minw, minh, maxw, maxh int) {
 
 // IsWindowFloating reports whether the window is always shown above all the other windows.
 //
+// In fullscreen mode, IsWindowFloating returns the state that is restored when exiting fullscreen.
+//
 // IsWindowFloating returns false if the platform is not a desktop.
 //
 // IsWindowFloating is concurrent-safe.
@@ -223,7 +239,12 @@ func IsWindowFloating() bool {
 }
 
 // SetWindowFloating sets the state whether the window is always shown above all the other windows.
+// The default state is false.
+//
+// A window in fullscreen mode is not floating. The floating state is restored when exiting fullscreen.
+// If SetWindowFloating is called in fullscreen mode, the state is applied when exiting fullscreen.
 //
+// SetWindowFloating works on Windows, macOS, and Linux with X11.
 // SetWindowFloating does nothing if the platform is not a desktop.
 //
 // SetWindowFloating is concurrent-safe.
@@ -325,7 +346,11 @@ func IsWindowClosingHandled() bool {
 // Even if this is set true, some platforms might require a window to be undecorated
 // in order to make the mouse cursor passthrough the window.
 //
-// SetWindowMousePassthrough works only on desktops.
+// The whole window becomes passthrough regardless of the alpha values of the pixels.
+// For an overlay application, combine this with RunGameOptions.ScreenTransparent and an undecorated window.
+//
+// SetWindowMousePassthrough works on Windows, macOS, and Linux with X11.
+// On X11, the XShape extension is required.
 // SetWindowMousePassthrough does nothing if the platform is not a desktop.
 //
 // SetWindowMousePassthrough is concurrent-safe.
@@ -341,3 +366,34 @@ func SetWindowMousePassthrough(enabled bool) {
 func IsWindowMousePassthrough() bool {
 	return ui.Get().Window().IsMousePassthrough()
 }
+
+// SetWindowOpacity sets the opacity of the whole window including its decorations.
+// opacity is clamped to the range [0, 1], where 0 is fully transparent and 1 is fully opaque.
+// The default value is 1.
+//
+// Unlike RunGameOptions.ScreenTransparent, SetWindowOpacity can be changed at any time,
+// and affects all the pixels of the window uniformly.
+//
+// SetWindowOpacity works on Windows, macOS, and Linux with X11.
+// On X11, a compositing window manager is required.
+// SetWindowOpacity does nothing if the platform is not a desktop.
+//
+// SetWindowOpacity is concurrent-safe.
+func SetWindowOpacity(opacity float64) {
+	if !(opacity >= 0) {
+		opacity = 0
+	}
+	if opacity > 1 {
+		opacity = 1
+	}
+	ui.Get().Window().SetOpacity(opacity)
+}
+
+// WindowOpacity returns the opacity of the window.
+//
+// WindowOpacity returns 1 if the platform doesn't support the window opacity or is not a desktop.
+//
+// WindowOpacity is concurrent-safe.
+func WindowOpacity() float64 {
+	return ui.Get().Window().Opacity()
+}